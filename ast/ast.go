@@ -1,6 +1,9 @@
 package ast
 
+import "github.com/jumballaya/servo/token"
+
 type Node interface {
 	TokenLiteral() string
 	String() string
+	Pos() token.Position
 }
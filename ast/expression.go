@@ -21,6 +21,7 @@ type PrefixExpression struct {
 
 func (pe *PrefixExpression) expressionNode()      {}
 func (pe *PrefixExpression) TokenLiteral() string { return pe.Token.Literal }
+func (pe *PrefixExpression) Pos() token.Position  { return pe.Token.Pos }
 func (pe *PrefixExpression) String() string {
 	var out bytes.Buffer
 
@@ -41,6 +42,7 @@ type InfixExpression struct {
 
 func (oe *InfixExpression) expressionNode()      {}
 func (oe *InfixExpression) TokenLiteral() string { return oe.Token.Literal }
+func (oe *InfixExpression) Pos() token.Position  { return oe.Token.Pos }
 func (oe *InfixExpression) String() string {
 	var out bytes.Buffer
 
@@ -62,6 +64,7 @@ type IfExpression struct {
 
 func (ie *IfExpression) expressionNode()      {}
 func (ie *IfExpression) TokenLiteral() string { return ie.Token.Literal }
+func (ie *IfExpression) Pos() token.Position  { return ie.Token.Pos }
 func (ie *IfExpression) String() string {
 	var out bytes.Buffer
 
@@ -78,6 +81,69 @@ func (ie *IfExpression) String() string {
 	return out.String()
 }
 
+// Ternary Expression picks one of two values, e.g. `cond ? a : b`
+type TernaryExpression struct {
+	Token       token.Token // the '?' token
+	Condition   Expression
+	Consequence Expression
+	Alternative Expression
+}
+
+func (te *TernaryExpression) expressionNode()      {}
+func (te *TernaryExpression) TokenLiteral() string { return te.Token.Literal }
+func (te *TernaryExpression) Pos() token.Position  { return te.Token.Pos }
+func (te *TernaryExpression) String() string {
+	return "(" + te.Condition.String() + " ? " + te.Consequence.String() + " : " + te.Alternative.String() + ")"
+}
+
+// Match Expression runs the body of the first arm whose pattern matches the subject, e.g.
+// `match (x) { 0 => "zero", n if n < 0 => "negative", _ => "positive" }`
+type MatchExpression struct {
+	Token   token.Token // the 'match' token
+	Subject Expression
+	Arms    []*MatchArm
+}
+
+// Match Arm is a single `pattern if guard => body` case of a match expression
+type MatchArm struct {
+	Pattern Pattern
+	Guard   Expression // optional
+	Body    *BlockStatement
+}
+
+func (ma *MatchArm) String() string {
+	var out bytes.Buffer
+
+	out.WriteString(ma.Pattern.String())
+	if ma.Guard != nil {
+		out.WriteString(" if " + ma.Guard.String())
+	}
+	out.WriteString(" => ")
+	out.WriteString(ma.Body.String())
+
+	return out.String()
+}
+
+func (me *MatchExpression) expressionNode()      {}
+func (me *MatchExpression) TokenLiteral() string { return me.Token.Literal }
+func (me *MatchExpression) Pos() token.Position  { return me.Token.Pos }
+func (me *MatchExpression) String() string {
+	var out bytes.Buffer
+
+	arms := []string{}
+	for _, arm := range me.Arms {
+		arms = append(arms, arm.String())
+	}
+
+	out.WriteString("match (")
+	out.WriteString(me.Subject.String())
+	out.WriteString(") { ")
+	out.WriteString(strings.Join(arms, ", "))
+	out.WriteString(" }")
+
+	return out.String()
+}
+
 type CallExpression struct {
 	Token     token.Token // '(' token
 	Function  Expression
@@ -86,6 +152,7 @@ type CallExpression struct {
 
 func (ce *CallExpression) expressionNode()      {}
 func (ce *CallExpression) TokenLiteral() string { return ce.Token.Literal }
+func (ce *CallExpression) Pos() token.Position  { return ce.Token.Pos }
 func (ce *CallExpression) String() string {
 	var out bytes.Buffer
 
@@ -111,6 +178,7 @@ type IndexExpression struct {
 
 func (ie *IndexExpression) expressionNode()      {}
 func (ie *IndexExpression) TokenLiteral() string { return ie.Token.Literal }
+func (ie *IndexExpression) Pos() token.Position  { return ie.Token.Pos }
 func (ie *IndexExpression) String() string {
 	var out bytes.Buffer
 	out.WriteString("(")
@@ -122,52 +190,173 @@ func (ie *IndexExpression) String() string {
 	return out.String()
 }
 
-type ImportExpression struct {
-	Token token.Token
-	Path  *StringLiteral
+// Slice Expression is an index expression with colons, e.g. `arr[1:3]` or `str[::-1]`. Any of
+// the bounds can be left out, in which case they are nil.
+type SliceExpression struct {
+	Token token.Token // the '[' token
+	Left  Expression
+	Start Expression
+	End   Expression
+	Step  Expression
+}
+
+func (se *SliceExpression) expressionNode()      {}
+func (se *SliceExpression) TokenLiteral() string { return se.Token.Literal }
+func (se *SliceExpression) Pos() token.Position  { return se.Token.Pos }
+func (se *SliceExpression) String() string {
+	var out bytes.Buffer
+
+	bound := func(exp Expression) string {
+		if exp == nil {
+			return ""
+		}
+		return exp.String()
+	}
+
+	out.WriteString("(")
+	out.WriteString(se.Left.String())
+	out.WriteString("[")
+	out.WriteString(bound(se.Start) + ":" + bound(se.End))
+	if se.Step != nil {
+		out.WriteString(":" + se.Step.String())
+	}
+	out.WriteString("])")
+	return out.String()
+}
+
+// Spread Expression expands an array into the arguments of a call or the elements of an
+// array literal, e.g. `f(...args)`
+type SpreadExpression struct {
+	Token token.Token // the '...' token
+	Value Expression
+}
+
+func (se *SpreadExpression) expressionNode()      {}
+func (se *SpreadExpression) TokenLiteral() string { return se.Token.Literal }
+func (se *SpreadExpression) Pos() token.Position  { return se.Token.Pos }
+func (se *SpreadExpression) String() string       { return "..." + se.Value.String() }
+
+// Named Argument passes an argument by the name of the parameter, e.g. `listen(port: 8080)`
+type NamedArgument struct {
+	Token token.Token // the name's token
 	Name  *Identifier
-	Value string
+	Value Expression
+}
+
+func (na *NamedArgument) expressionNode()      {}
+func (na *NamedArgument) TokenLiteral() string { return na.Token.Literal }
+func (na *NamedArgument) Pos() token.Position  { return na.Token.Pos }
+func (na *NamedArgument) String() string       { return na.Name.String() + ": " + na.Value.String() }
+
+// Import Expression binds names exported by another module, or a namespace holding all of them
+type ImportExpression struct {
+	Token      token.Token
+	Path       *StringLiteral
+	Name       *Identifier        // `import name from 'path'`
+	Specifiers []*ImportSpecifier // `import {a, b as c} from 'path'`
+	Namespace  *Identifier        // `import 'path' as name`, binds every export
+	Value      string
 }
 
 func (ie *ImportExpression) expressionNode()      {}
 func (ie *ImportExpression) TokenLiteral() string { return ie.Token.Literal }
+func (ie *ImportExpression) Pos() token.Position  { return ie.Token.Pos }
 func (ie *ImportExpression) String() string {
-	return fmt.Sprintf("import %s as %s;", ie.Path.String(), ie.Name.String())
+	if ie.Namespace != nil {
+		return fmt.Sprintf("import '%s' as %s;", ie.Path.String(), ie.Namespace.String())
+	}
+	if ie.Name != nil {
+		return fmt.Sprintf("import %s from '%s';", ie.Name.String(), ie.Path.String())
+	}
+
+	specifiers := []string{}
+	for _, s := range ie.Specifiers {
+		specifiers = append(specifiers, s.String())
+	}
+	return fmt.Sprintf("import {%s} from '%s';", strings.Join(specifiers, ", "), ie.Path.String())
+}
+
+// Imports gives back the specifiers of the names the import binds, `import name from 'path'`
+// is the same as `import {name} from 'path'`. Namespace imports don't have any.
+func (ie *ImportExpression) Imports() []*ImportSpecifier {
+	if ie.Name != nil {
+		return []*ImportSpecifier{{Name: ie.Name}}
+	}
+	return ie.Specifiers
+}
+
+// Import Specifier is one of the names in `import {a, b as c} from 'path'`. Alias is only set
+// when the name is renamed.
+type ImportSpecifier struct {
+	Name  *Identifier
+	Alias *Identifier
 }
 
+// Local gives back the name the import is bound to in the importing module
+func (is *ImportSpecifier) Local() *Identifier {
+	if is.Alias != nil {
+		return is.Alias
+	}
+	return is.Name
+}
+
+func (is *ImportSpecifier) String() string {
+	if is.Alias != nil {
+		return is.Name.String() + " as " + is.Alias.String()
+	}
+	return is.Name.String()
+}
+
+// Assign Expression sets an identifier, index or attribute. Operator holds the infix operator
+// of a compound assignment, e.g. "+" for `x += 1`, and is empty for a plain `=`.
 type AssignExpression struct {
-	Token token.Token
-	Left  Expression
-	Value Expression
+	Token    token.Token
+	Left     Expression
+	Operator string
+	Value    Expression
 }
 
 func (as *AssignExpression) expressionNode()      {}
 func (as *AssignExpression) TokenLiteral() string { return as.Token.Literal }
+func (as *AssignExpression) Pos() token.Position  { return as.Token.Pos }
 func (as *AssignExpression) String() string {
+	return as.assignment() + ";"
+}
+
+// Assignment prints the assignment without the `;`, so `x = y = 5` only ends with one
+func (as *AssignExpression) assignment() string {
 	var out bytes.Buffer
 
 	out.WriteString(as.Left.String())
-	out.WriteString(" = ")
-	if as.Value != nil {
+	out.WriteString(" " + as.Operator + "= ")
+	if nested, ok := as.Value.(*AssignExpression); ok {
+		out.WriteString(nested.assignment())
+	} else if as.Value != nil {
 		out.WriteString(as.Value.String())
 	}
-	out.WriteByte(';')
 	return out.String()
 }
 
+// Attribute Expression reads a field, e.g. `foo.bar`. Optional is set for `foo?.bar`, which is
+// null instead of an error when foo is null.
 type AttributeExpression struct {
-	Token token.Token
-	Left  Expression
-	Index *StringLiteral
+	Token    token.Token
+	Left     Expression
+	Index    *StringLiteral
+	Optional bool
 }
 
 func (ae *AttributeExpression) expressionNode()      {}
 func (ae *AttributeExpression) TokenLiteral() string { return ae.Token.Literal }
+func (ae *AttributeExpression) Pos() token.Position  { return ae.Token.Pos }
 func (ae *AttributeExpression) String() string {
 	var out bytes.Buffer
 
 	out.WriteString("(")
 	out.WriteString(ae.Left.String())
+	if ae.Optional {
+		out.WriteString("?")
+	}
 	out.WriteString(".")
 	out.WriteString(ae.Index.String())
 	out.WriteString(")")
@@ -3,6 +3,7 @@ package ast
 import (
 	"bytes"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/jumballaya/servo/token"
@@ -15,6 +16,7 @@ type Identifier struct {
 
 func (i *Identifier) expressionNode()      {}
 func (i *Identifier) TokenLiteral() string { return i.Token.Literal }
+func (i *Identifier) Pos() token.Position  { return i.Token.Pos }
 func (i *Identifier) String() string       { return i.Value }
 
 type StringLiteral struct {
@@ -24,8 +26,43 @@ type StringLiteral struct {
 
 func (sl *StringLiteral) expressionNode()      {}
 func (sl *StringLiteral) TokenLiteral() string { return sl.Token.Literal }
+func (sl *StringLiteral) Pos() token.Position  { return sl.Token.Pos }
 func (sl *StringLiteral) String() string       { return sl.Token.Literal }
 
+type BytesLiteral struct {
+	Token token.Token
+	Value []byte
+}
+
+func (bl *BytesLiteral) expressionNode()      {}
+func (bl *BytesLiteral) TokenLiteral() string { return bl.Token.Literal }
+func (bl *BytesLiteral) Pos() token.Position  { return bl.Token.Pos }
+func (bl *BytesLiteral) String() string       { return "b" + strconv.Quote(bl.Token.Literal) }
+
+type TemplateLiteral struct {
+	Token token.Token  // first template token
+	Parts []Expression // text chunks are *StringLiteral, everything else is a substitution
+}
+
+func (tl *TemplateLiteral) expressionNode()      {}
+func (tl *TemplateLiteral) TokenLiteral() string { return tl.Token.Literal }
+func (tl *TemplateLiteral) Pos() token.Position  { return tl.Token.Pos }
+func (tl *TemplateLiteral) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("`")
+	for _, part := range tl.Parts {
+		if str, ok := part.(*StringLiteral); ok {
+			out.WriteString(str.Value)
+		} else {
+			out.WriteString("${" + part.String() + "}")
+		}
+	}
+	out.WriteString("`")
+
+	return out.String()
+}
+
 type IntegerLiteral struct {
 	Token token.Token
 	Value int64
@@ -33,6 +70,7 @@ type IntegerLiteral struct {
 
 func (il *IntegerLiteral) expressionNode()      {}
 func (il *IntegerLiteral) TokenLiteral() string { return il.Token.Literal }
+func (il *IntegerLiteral) Pos() token.Position  { return il.Token.Pos }
 func (il *IntegerLiteral) String() string       { return il.Token.Literal }
 
 type FloatLiteral struct {
@@ -42,17 +80,9 @@ type FloatLiteral struct {
 
 func (fl *FloatLiteral) expressionNode()      {}
 func (fl *FloatLiteral) TokenLiteral() string { return fl.Token.Literal }
+func (fl *FloatLiteral) Pos() token.Position  { return fl.Token.Pos }
 func (fl *FloatLiteral) String() string       { return fl.Token.Literal }
 
-type CommentLiteral struct {
-	Token token.Token
-	Value string
-}
-
-func (cl *CommentLiteral) expressionNode()      {}
-func (cl *CommentLiteral) TokenLiteral() string { return cl.Token.Literal }
-func (cl *CommentLiteral) String() string       { return cl.Token.Literal }
-
 type ArrayLiteral struct {
 	Token    token.Token // '[' token
 	Elements []Expression
@@ -60,6 +90,7 @@ type ArrayLiteral struct {
 
 func (al *ArrayLiteral) expressionNode()      {}
 func (al *ArrayLiteral) TokenLiteral() string { return al.Token.Literal }
+func (al *ArrayLiteral) Pos() token.Position  { return al.Token.Pos }
 func (al *ArrayLiteral) String() string {
 	var out bytes.Buffer
 
@@ -82,6 +113,7 @@ type HashLiteral struct {
 
 func (hl *HashLiteral) expressionNode()      {}
 func (hl *HashLiteral) TokenLiteral() string { return hl.Token.Literal }
+func (hl *HashLiteral) Pos() token.Position  { return hl.Token.Pos }
 func (hl *HashLiteral) String() string {
 	var out bytes.Buffer
 	pairs := []string{}
@@ -98,13 +130,18 @@ func (hl *HashLiteral) String() string {
 }
 
 type FunctionLiteral struct {
-	Token      token.Token // 'fn' token
-	Parameters []*Identifier
+	Token      token.Token // 'fn' token, or '=>' for arrow functions
+	Name       string      // name the function is bound to, used in error messages
+	Parameters []Pattern
+	Rest       *Identifier // optional rest parameter, e.g. `...others`
 	Body       *BlockStatement
+	Doc        string // `##` doc comment right before the function
+	Arrow      bool   // written as `(a, b) => body`
 }
 
 func (fl *FunctionLiteral) expressionNode()      {}
 func (fl *FunctionLiteral) TokenLiteral() string { return fl.Token.Literal }
+func (fl *FunctionLiteral) Pos() token.Position  { return fl.Token.Pos }
 func (fl *FunctionLiteral) String() string {
 	var out bytes.Buffer
 
@@ -113,6 +150,17 @@ func (fl *FunctionLiteral) String() string {
 	for _, p := range fl.Parameters {
 		params = append(params, p.String())
 	}
+	if fl.Rest != nil {
+		params = append(params, "..."+fl.Rest.String())
+	}
+
+	if fl.Arrow {
+		out.WriteString("(")
+		out.WriteString(strings.Join(params, ", "))
+		out.WriteString(") => ")
+		out.WriteString(fl.Body.String())
+		return out.String()
+	}
 
 	out.WriteString(fl.TokenLiteral())
 	out.WriteString("(")
@@ -130,6 +178,7 @@ type BooleanLiteral struct {
 
 func (b *BooleanLiteral) expressionNode()      {}
 func (b *BooleanLiteral) TokenLiteral() string { return b.Token.Literal }
+func (b *BooleanLiteral) Pos() token.Position  { return b.Token.Pos }
 func (b *BooleanLiteral) String() string       { return b.Token.Literal }
 
 type NullLiteral struct {
@@ -138,6 +187,7 @@ type NullLiteral struct {
 
 func (n *NullLiteral) expressionNode()      {}
 func (n *NullLiteral) TokenLiteral() string { return n.Token.Literal }
+func (n *NullLiteral) Pos() token.Position  { return n.Token.Pos }
 func (n *NullLiteral) String() string       { return n.Token.Literal }
 
 type ClassLiteral struct {
@@ -146,21 +196,25 @@ type ClassLiteral struct {
 	Parent  string
 	Fields  []*LetStatement
 	Methods map[string]*FunctionLiteral
+	Doc     string // `##` doc comment right before the class
 }
 
 func (c *ClassLiteral) expressionNode()      {}
 func (c *ClassLiteral) TokenLiteral() string { return c.Token.Literal }
+func (c *ClassLiteral) Pos() token.Position  { return c.Token.Pos }
 func (c *ClassLiteral) String() string {
 	return fmt.Sprintf("class %s::%s {...}", c.Name, c.Parent)
 }
 
 type InstanceLiteral struct {
+	Token     token.Token // 'new' token
 	Class     Expression
 	Arguments []Expression
 }
 
 func (il *InstanceLiteral) expressionNode()      {}
 func (il *InstanceLiteral) TokenLiteral() string { return "new" }
+func (il *InstanceLiteral) Pos() token.Position  { return il.Token.Pos }
 func (il *InstanceLiteral) String() string {
 	args := []string{}
 	for _, a := range il.Arguments {
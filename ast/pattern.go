@@ -0,0 +1,192 @@
+package ast
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/jumballaya/servo/token"
+)
+
+// Pattern is the target of a binding in a let statement or a function parameter list. The
+// simplest pattern is an identifier; the others destructure arrays and hashes.
+type Pattern interface {
+	Expression
+	patternNode()
+}
+
+func (i *Identifier) patternNode() {}
+
+// Array Pattern binds the elements of an array in order, e.g. `[a, b, ...rest]`
+type ArrayPattern struct {
+	Token    token.Token // the '[' token
+	Elements []Pattern
+	Rest     *Identifier // optional, gets the elements that are left over
+}
+
+func (ap *ArrayPattern) expressionNode()      {}
+func (ap *ArrayPattern) patternNode()         {}
+func (ap *ArrayPattern) TokenLiteral() string { return ap.Token.Literal }
+func (ap *ArrayPattern) Pos() token.Position  { return ap.Token.Pos }
+func (ap *ArrayPattern) String() string {
+	var out bytes.Buffer
+
+	elements := []string{}
+	for _, el := range ap.Elements {
+		elements = append(elements, el.String())
+	}
+	if ap.Rest != nil {
+		elements = append(elements, "..."+ap.Rest.String())
+	}
+
+	out.WriteString("[")
+	out.WriteString(strings.Join(elements, ", "))
+	out.WriteString("]")
+
+	return out.String()
+}
+
+// Hash Pattern Entry binds the value under Key to a pattern, e.g. `age: years`
+type HashPatternEntry struct {
+	Key   *StringLiteral
+	Value Pattern
+}
+
+// Hash Pattern binds string keys of a hash, e.g. `{name, age: years, ...rest}`
+type HashPattern struct {
+	Token   token.Token // the '{' token
+	Entries []*HashPatternEntry
+	Rest    *Identifier // optional, gets a hash of the keys that are left over
+}
+
+func (hp *HashPattern) expressionNode()      {}
+func (hp *HashPattern) patternNode()         {}
+func (hp *HashPattern) TokenLiteral() string { return hp.Token.Literal }
+func (hp *HashPattern) Pos() token.Position  { return hp.Token.Pos }
+func (hp *HashPattern) String() string {
+	var out bytes.Buffer
+
+	entries := []string{}
+	for _, entry := range hp.Entries {
+		// Shorthand entries like `{name}` or `{port = 80}` bind the key to an identifier of the
+		// same name
+		target := entry.Value
+		if def, ok := target.(*DefaultPattern); ok {
+			target = def.Target
+		}
+		if ident, ok := target.(*Identifier); ok && ident.Value == entry.Key.Value {
+			entries = append(entries, entry.Value.String())
+			continue
+		}
+		entries = append(entries, entry.Key.String()+": "+entry.Value.String())
+	}
+	if hp.Rest != nil {
+		entries = append(entries, "..."+hp.Rest.String())
+	}
+
+	out.WriteString("{")
+	out.WriteString(strings.Join(entries, ", "))
+	out.WriteString("}")
+
+	return out.String()
+}
+
+// Default Pattern gives a pattern a value to use when there is nothing to bind, e.g. `port = 80`
+type DefaultPattern struct {
+	Token   token.Token // the '=' token
+	Target  Pattern
+	Default Expression
+}
+
+func (dp *DefaultPattern) expressionNode()      {}
+func (dp *DefaultPattern) patternNode()         {}
+func (dp *DefaultPattern) TokenLiteral() string { return dp.Token.Literal }
+func (dp *DefaultPattern) Pos() token.Position  { return dp.Target.Pos() }
+func (dp *DefaultPattern) String() string {
+	return dp.Target.String() + " = " + dp.Default.String()
+}
+
+// Wildcard Pattern matches any value without binding it, e.g. `_`
+type WildcardPattern struct {
+	Token token.Token // the '_' token
+}
+
+func (wp *WildcardPattern) expressionNode()      {}
+func (wp *WildcardPattern) patternNode()         {}
+func (wp *WildcardPattern) TokenLiteral() string { return wp.Token.Literal }
+func (wp *WildcardPattern) Pos() token.Position  { return wp.Token.Pos }
+func (wp *WildcardPattern) String() string       { return "_" }
+
+// Literal Pattern matches a value equal to the literal, e.g. `"GET"` or `-1`
+type LiteralPattern struct {
+	Token token.Token // the first token of the literal
+	Value Expression
+}
+
+func (lp *LiteralPattern) expressionNode()      {}
+func (lp *LiteralPattern) patternNode()         {}
+func (lp *LiteralPattern) TokenLiteral() string { return lp.Token.Literal }
+func (lp *LiteralPattern) Pos() token.Position  { return lp.Token.Pos }
+func (lp *LiteralPattern) String() string       { return lp.Value.String() }
+
+// Range Pattern matches a number or string between two bounds, both included, e.g. `1..10`
+type RangePattern struct {
+	Token token.Token // the '..' token
+	Low   Expression
+	High  Expression
+}
+
+func (rp *RangePattern) expressionNode()      {}
+func (rp *RangePattern) patternNode()         {}
+func (rp *RangePattern) TokenLiteral() string { return rp.Token.Literal }
+func (rp *RangePattern) Pos() token.Position  { return rp.Low.Pos() }
+func (rp *RangePattern) String() string {
+	return rp.Low.String() + ".." + rp.High.String()
+}
+
+// Class Pattern matches instances of a class or its subclasses, optionally binding the
+// instance, e.g. `instanceof Car` or `car instanceof Car`
+type ClassPattern struct {
+	Token token.Token // the 'instanceof' token
+	Name  *Identifier // optional
+	Class *Identifier
+}
+
+func (cp *ClassPattern) expressionNode()      {}
+func (cp *ClassPattern) patternNode()         {}
+func (cp *ClassPattern) TokenLiteral() string { return cp.Token.Literal }
+func (cp *ClassPattern) Pos() token.Position  { return cp.Token.Pos }
+func (cp *ClassPattern) String() string {
+	if cp.Name != nil {
+		return cp.Name.String() + " instanceof " + cp.Class.String()
+	}
+	return "instanceof " + cp.Class.String()
+}
+
+// Pattern Names gives back every name a binding pattern binds, in order
+func PatternNames(pattern Pattern) []string {
+	switch pattern := pattern.(type) {
+	case *Identifier:
+		return []string{pattern.Value}
+	case *DefaultPattern:
+		return PatternNames(pattern.Target)
+	case *ArrayPattern:
+		names := []string{}
+		for _, el := range pattern.Elements {
+			names = append(names, PatternNames(el)...)
+		}
+		if pattern.Rest != nil {
+			names = append(names, pattern.Rest.Value)
+		}
+		return names
+	case *HashPattern:
+		names := []string{}
+		for _, entry := range pattern.Entries {
+			names = append(names, PatternNames(entry.Value)...)
+		}
+		if pattern.Rest != nil {
+			names = append(names, pattern.Rest.Value)
+		}
+		return names
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package ast
 
 import (
 	"bytes"
+	"strings"
 
 	"github.com/jumballaya/servo/token"
 )
@@ -23,6 +24,13 @@ func (p *Program) TokenLiteral() string {
 	}
 }
 
+func (p *Program) Pos() token.Position {
+	if len(p.Statements) > 0 {
+		return p.Statements[0].Pos()
+	}
+	return token.Position{}
+}
+
 func (p *Program) String() string {
 	var out bytes.Buffer
 
@@ -33,19 +41,28 @@ func (p *Program) String() string {
 	return out.String()
 }
 
+// Let Statement binds a value to a name, or destructures it when Pattern is set
+// e.g. `let x = 5;` or `let [a, b] = pair;`
 type LetStatement struct {
-	Token token.Token
-	Name  *Identifier
-	Value Expression
+	Token   token.Token
+	Name    *Identifier
+	Pattern Pattern // destructuring pattern, Name is nil when this is set
+	Value   Expression
+	Doc     string // `##` doc comment right before the statement
 }
 
 func (ls *LetStatement) statementNode()       {}
 func (ls *LetStatement) TokenLiteral() string { return ls.Token.Literal }
+func (ls *LetStatement) Pos() token.Position  { return ls.Token.Pos }
 func (ls *LetStatement) String() string {
 	var out bytes.Buffer
 
 	out.WriteString(ls.TokenLiteral() + " ")
-	out.WriteString(ls.Name.String())
+	if ls.Pattern != nil {
+		out.WriteString(ls.Pattern.String())
+	} else {
+		out.WriteString(ls.Name.String())
+	}
 	out.WriteString(" = ")
 
 	if ls.Value != nil {
@@ -56,6 +73,50 @@ func (ls *LetStatement) String() string {
 	return out.String()
 }
 
+// Function Statement declares a named function, e.g. `fn add(a, b) { a + b }`. Declarations
+// are hoisted to the top of their block, so they can be called before the statement runs.
+type FunctionStatement struct {
+	Token    token.Token // the 'fn' token
+	Name     *Identifier
+	Function *FunctionLiteral
+}
+
+func (fs *FunctionStatement) statementNode()       {}
+func (fs *FunctionStatement) TokenLiteral() string { return fs.Token.Literal }
+func (fs *FunctionStatement) Pos() token.Position  { return fs.Token.Pos }
+func (fs *FunctionStatement) String() string {
+	// The literal prints as `fn(params) body`, the name goes right after the `fn`
+	return fs.TokenLiteral() + " " + fs.Name.String() + strings.TrimPrefix(fs.Function.String(), fs.Function.TokenLiteral())
+}
+
+// Export Statement makes the names a top-level declaration binds importable from other modules,
+// e.g. `export let x = 1` or `export fn add(a, b) { a + b }`
+type ExportStatement struct {
+	Token     token.Token // the 'export' token
+	Statement Statement   // a let statement, class declaration or function statement
+}
+
+func (es *ExportStatement) statementNode()       {}
+func (es *ExportStatement) TokenLiteral() string { return es.Token.Literal }
+func (es *ExportStatement) Pos() token.Position  { return es.Token.Pos }
+func (es *ExportStatement) String() string {
+	return es.TokenLiteral() + " " + es.Statement.String()
+}
+
+// Names gives back every name the exported declaration binds, in order
+func (es *ExportStatement) Names() []string {
+	switch stmt := es.Statement.(type) {
+	case *LetStatement:
+		if stmt.Pattern != nil {
+			return PatternNames(stmt.Pattern)
+		}
+		return []string{stmt.Name.Value}
+	case *FunctionStatement:
+		return []string{stmt.Name.Value}
+	}
+	return nil
+}
+
 type ReturnStatement struct {
 	Token       token.Token
 	ReturnValue Expression
@@ -63,6 +124,7 @@ type ReturnStatement struct {
 
 func (rs *ReturnStatement) statementNode()       {}
 func (rs *ReturnStatement) TokenLiteral() string { return rs.Token.Literal }
+func (rs *ReturnStatement) Pos() token.Position  { return rs.Token.Pos }
 func (rs *ReturnStatement) String() string {
 	var out bytes.Buffer
 
@@ -83,6 +145,7 @@ type ExpressionStatement struct {
 
 func (es *ExpressionStatement) statementNode()       {}
 func (es *ExpressionStatement) TokenLiteral() string { return es.Token.Literal }
+func (es *ExpressionStatement) Pos() token.Position  { return es.Token.Pos }
 func (es *ExpressionStatement) String() string {
 	if es.Expression != nil {
 		return es.Expression.String()
@@ -97,6 +160,7 @@ type BlockStatement struct {
 
 func (bs *BlockStatement) statementNode()       {}
 func (bs *BlockStatement) TokenLiteral() string { return bs.Token.Literal }
+func (bs *BlockStatement) Pos() token.Position  { return bs.Token.Pos }
 func (bs *BlockStatement) String() string {
 	var out bytes.Buffer
 	for _, s := range bs.Statements {
@@ -104,3 +168,106 @@ func (bs *BlockStatement) String() string {
 	}
 	return out.String()
 }
+
+type ForStatement struct {
+	Token    token.Token // the 'for' token
+	Key      *Identifier // optional, e.g. k in `for (k, v in hash)`
+	Value    *Identifier
+	Iterable Expression
+	Body     *BlockStatement
+}
+
+func (fs *ForStatement) statementNode()       {}
+func (fs *ForStatement) TokenLiteral() string { return fs.Token.Literal }
+func (fs *ForStatement) Pos() token.Position  { return fs.Token.Pos }
+func (fs *ForStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("for (")
+	if fs.Key != nil {
+		out.WriteString(fs.Key.String() + ", ")
+	}
+	out.WriteString(fs.Value.String())
+	out.WriteString(" in ")
+	out.WriteString(fs.Iterable.String())
+	out.WriteString(") ")
+	out.WriteString(fs.Body.String())
+
+	return out.String()
+}
+
+type WhileStatement struct {
+	Token     token.Token // the 'while' token
+	Condition Expression
+	Body      *BlockStatement
+}
+
+func (ws *WhileStatement) statementNode()       {}
+func (ws *WhileStatement) TokenLiteral() string { return ws.Token.Literal }
+func (ws *WhileStatement) Pos() token.Position  { return ws.Token.Pos }
+func (ws *WhileStatement) String() string {
+	return "while " + ws.Condition.String() + " " + ws.Body.String()
+}
+
+type BreakStatement struct {
+	Token token.Token
+}
+
+func (bs *BreakStatement) statementNode()       {}
+func (bs *BreakStatement) TokenLiteral() string { return bs.Token.Literal }
+func (bs *BreakStatement) Pos() token.Position  { return bs.Token.Pos }
+func (bs *BreakStatement) String() string       { return bs.Token.Literal + ";" }
+
+type ContinueStatement struct {
+	Token token.Token
+}
+
+func (cs *ContinueStatement) statementNode()       {}
+func (cs *ContinueStatement) TokenLiteral() string { return cs.Token.Literal }
+func (cs *ContinueStatement) Pos() token.Position  { return cs.Token.Pos }
+func (cs *ContinueStatement) String() string       { return cs.Token.Literal + ";" }
+
+type TryStatement struct {
+	Token      token.Token // the 'try' token
+	Block      *BlockStatement
+	CatchParam *Identifier // optional, e.g. e in `catch (e) { ... }`
+	Catch      *BlockStatement
+	Finally    *BlockStatement
+}
+
+func (ts *TryStatement) statementNode()       {}
+func (ts *TryStatement) TokenLiteral() string { return ts.Token.Literal }
+func (ts *TryStatement) Pos() token.Position  { return ts.Token.Pos }
+func (ts *TryStatement) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("try ")
+	out.WriteString(ts.Block.String())
+
+	if ts.Catch != nil {
+		out.WriteString(" catch ")
+		if ts.CatchParam != nil {
+			out.WriteString("(" + ts.CatchParam.String() + ") ")
+		}
+		out.WriteString(ts.Catch.String())
+	}
+
+	if ts.Finally != nil {
+		out.WriteString(" finally ")
+		out.WriteString(ts.Finally.String())
+	}
+
+	return out.String()
+}
+
+type ThrowStatement struct {
+	Token token.Token // the 'throw' token
+	Value Expression
+}
+
+func (ts *ThrowStatement) statementNode()       {}
+func (ts *ThrowStatement) TokenLiteral() string { return ts.Token.Literal }
+func (ts *ThrowStatement) Pos() token.Position  { return ts.Token.Pos }
+func (ts *ThrowStatement) String() string {
+	return ts.TokenLiteral() + " " + ts.Value.String() + ";"
+}
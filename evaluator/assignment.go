@@ -2,13 +2,9 @@ package evaluator
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
-	"strings"
 
 	"github.com/jumballaya/servo/ast"
 	"github.com/jumballaya/servo/object"
-	"github.com/jumballaya/servo/token"
 )
 
 // Eval Let Statement
@@ -17,48 +13,146 @@ func evalLetStatement(node *ast.LetStatement, env *object.Environment) object.Ob
 	if isError(val) {
 		return val
 	}
+
+	if node.Pattern != nil {
+		if err := bindPattern(node.Pattern, val, env); err != nil {
+			return err
+		}
+		return val
+	}
+
 	return env.Set(node.Name.Value, val)
 }
 
-// Eval Assignment Statement
+// Eval Assignment Statement sets an identifier, index or attribute. The target's container and
+// key are evaluated once, so `next()[i()] += 1` only calls next and i a single time.
 func evalAssignExpression(node *ast.AssignExpression, env *object.Environment) object.Object {
-	var Left ast.Expression
-
-	// Attribute Expression
-	attr, ok := node.Left.(*ast.AttributeExpression)
-	if ok {
-		instance := object.GetSelf(env)
-		val := evalAttributeExpression(attr, instance.Fields)
-		_, ok := val.(*object.Null)
-		// Identifier not set
-		if ok {
-			ident := &ast.Identifier{Token: token.Token{Type: token.IDENT, Literal: attr.Index.Value}, Value: attr.Index.Value}
-			evaluated := Eval(node.Value, env)
-			instance.Fields.Set(ident.Value, evaluated)
-			env.Set(ident.Value, evaluated)
-			return evaluated
+	get, set, err := evalAssignTarget(node.Left, env)
+	if err != nil {
+		return err
+	}
+
+	// Compound assignments read the current value before the right-hand side is evaluated
+	var current object.Object
+	if node.Operator != "" {
+		current = get()
+		if isError(current) {
+			return current
 		}
+	}
 
-		instance.Fields.Set(attr.Index.Value, val)
-		env.Set(attr.Index.Value, val)
+	val := Eval(node.Value, env)
+	if isError(val) {
 		return val
 	}
 
-	// Normal
-	Left = node.Left
+	if node.Operator != "" {
+		val = evalInfixExpression(node.Operator, current, val)
+		if isError(val) {
+			return val
+		}
+	}
 
-	val := Eval(node.Value, env)
-	if isError(val) {
+	return set(val)
+}
+
+// Eval Assign Target evaluates everything in an assignment target except the final step and
+// returns functions to read and write it
+func evalAssignTarget(exp ast.Expression, env *object.Environment) (func() object.Object, func(object.Object) object.Object, *object.Error) {
+	switch exp := exp.(type) {
+	case *ast.Identifier:
+		get := func() object.Object { return evalIdentifier(exp, env) }
+		set := func(val object.Object) object.Object {
+			// Update the variable where it was declared so loops and closures can change outer variables
+			if _, ok := env.Assign(exp.Value, val); ok {
+				return val
+			}
+			return env.Set(exp.Value, val)
+		}
+		return get, set, nil
+
+	case *ast.IndexExpression:
+		left := Eval(exp.Left, env)
+		if err, ok := left.(*object.Error); ok {
+			return nil, nil, err
+		}
+		index := Eval(exp.Index, env)
+		if err, ok := index.(*object.Error); ok {
+			return nil, nil, err
+		}
+		get := func() object.Object { return evalIndexExpression(left, index) }
+		set := func(val object.Object) object.Object { return assignIndex(left, index, val) }
+		return get, set, nil
+
+	case *ast.AttributeExpression:
+		target := Eval(exp.Left, env)
+		if err, ok := target.(*object.Error); ok {
+			return nil, nil, err
+		}
+		name := exp.Index.Value
+		get := func() object.Object { return getAttribute(target, name) }
+		set := func(val object.Object) object.Object { return assignAttribute(target, name, val) }
+		return get, set, nil
+	}
+
+	return nil, nil, newError("cannot assign to %s", exp.String())
+}
+
+// Assign Index sets an element of an array or a key of a hash e.g. `arr[0] = 1`
+func assignIndex(left, index, val object.Object) object.Object {
+	switch left := left.(type) {
+	case *object.Array:
+		i, ok := index.(*object.Integer)
+		if !ok {
+			return newError("array index must be INTEGER, got %s", index.Type())
+		}
+		id, ok := normalizeIndex(i.Value, len(left.Elements))
+		if !ok {
+			return newError("index %d out of range for array of length %d", i.Value, len(left.Elements))
+		}
+		left.Elements[id] = val
 		return val
+	case *object.Hash:
+		key, ok := index.(object.Hashable)
+		if !ok {
+			return newError("unusable as a hash key: %s", index.Type())
+		}
+		left.Pairs[key.HashKey()] = object.HashPair{Key: index, Value: val}
+		return val
+	default:
+		return newError("index assignment not supported: %s", left.Type())
 	}
+}
 
-	exp, _ := Left.(ast.Expression)
-	ident, ok := exp.(*ast.Identifier)
-	if !ok {
-		return newError("left value is not an identifier")
+// Get Attribute reads a field of an instance or a string key of a hash
+func getAttribute(target object.Object, name string) object.Object {
+	switch target := target.(type) {
+	case *object.Instance:
+		if val, ok := target.Fields.Get(name); ok {
+			return val
+		}
+		return NULL
+	case *object.Hash:
+		return evalHashIndexExpression(target, &object.String{Value: name})
+	default:
+		return newError("cannot get attribute %s of %s", name, target.Type())
 	}
+}
 
-	return env.Set(ident.Value, val)
+// Assign Attribute sets a field on an instance or a string key on a hash
+// e.g. `this.name = name` or `config.port = 8080`
+func assignAttribute(target object.Object, name string, val object.Object) object.Object {
+	switch target := target.(type) {
+	case *object.Instance:
+		target.Fields.Set(name, val)
+		return val
+	case *object.Hash:
+		key := &object.String{Value: name}
+		target.Pairs[key.HashKey()] = object.HashPair{Key: key, Value: val}
+		return val
+	default:
+		return newError("cannot set attribute %s on %s", name, target.Type())
+	}
 }
 
 // Eval Return Statement
@@ -79,57 +173,43 @@ func unwrapReturnValue(obj object.Object) object.Object {
 	return obj
 }
 
-// Eval Import Statement
+// Eval Import Statement binds names from another module, or a namespace hash with all of its
+// exports. File modules come from the module loader, so each one is only evaluated the first
+// time it is imported.
 func evalImportStatement(importExp *ast.ImportExpression, env *object.Environment) object.Object {
-	mod := importExp.Path.String()
-	obj := importExp.Name.String()
-
-	// Open the module's file
-	// Evaluate it
-	// Rip out the identifier that is being imported
-
-	// Comes from a file
-	if strings.HasPrefix(mod, "./") || strings.HasPrefix(mod, "../") || strings.HasPrefix(mod, "/") {
-		var currentFile string
-		if len(os.Args) < 2 {
-			currentFile = os.Args[0]
-		} else {
-			currentFile = os.Args[1]
-		}
-		currentDir := "./" + strings.Join(strings.Split(currentFile, "/")[:1], "/")
+	mod := importExp.Path.Value
 
-		dir, err := filepath.Abs(currentDir + "/" + mod)
+	var exports *object.Hash
+	if isLibraryImport(mod, env) {
+		// Comes from a library hash named after the module, like the standard lib
+		lib, _ := env.Get(mod)
+		exports = lib.(*object.Hash)
+	} else {
+		// Comes from a file, bare paths are found through servo.mod and SERVO_PATH
+		module, err := modules.loadImport(importExp, env.File)
 		if err != nil {
-			fmt.Println(err.Error())
-			return newError(err.Error())
+			return err
 		}
-		pulled := GetObjectFromFile(dir, obj)
-		env.Set(obj, pulled)
-		return NULL
-		// Comes from the standard lib
-	} else {
-		fn, ok := env.Get(mod)
-		if !ok {
-			return newError("improper import, function key not found")
+		if err := module.checkImports(importExp); err != nil {
+			return err
 		}
-
-		hash, ok := fn.(*object.Hash)
-		if !ok {
-			return newError("Must import off of an exported hash")
-		}
-
-		key := (&object.String{Value: obj}).HashKey()
-
-		found := hash.Pairs[key].Value
-		_, ok = found.(*object.Null)
-		if ok {
-			env.Set(obj, NULL)
-			return NULL
+		if result := modules.Evaluate(module); isError(result) {
+			return result
 		}
+		exports = module.Namespace()
+	}
 
-		env.Set(obj, found)
+	if importExp.Namespace != nil {
+		env.Set(importExp.Namespace.Value, exports)
 		return NULL
 	}
 
+	for _, spec := range importExp.Imports() {
+		pair, ok := exports.Pairs[(&object.String{Value: spec.Name.Value}).HashKey()]
+		if !ok {
+			return &object.Error{Message: fmt.Sprintf("%s is not exported by %s", spec.Name.Value, mod), Kind: "ImportError", Pos: spec.Name.Pos()}
+		}
+		env.Set(spec.Local().Value, pair.Value)
+	}
 	return NULL
 }
@@ -1,6 +1,10 @@
 package evaluator
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/jumballaya/servo/object"
+)
 
 func TestLetStatements(t *testing.T) {
 	tests := []struct {
@@ -66,3 +70,50 @@ f(10);`,
 		testIntegerObject(t, evaluated, tt.expected)
 	}
 }
+
+func TestAssignExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"let x = 10; x %= 3; x", 1},
+		{"let x = 2; x ^= 3; x", 8},
+		{"let x = 12; x |= 3; x", 15},
+		{"let x = 12; x &= 6; x", 4},
+		{"let x = 1; x <<= 4; x", 16},
+		{"let x = 16; x >>= 2; x", 4},
+		{"let arr = [1, 2, 3]; arr[0] = 10; arr[0] + arr[2]", 13},
+		{"let arr = [1, 2, 3]; arr[1] *= 5; arr[1]", 10},
+		{`let h = {"k": 1}; h["k"] += 2; h["k"]`, 3},
+		{`let h = {}; h[1] = 5; h[1]`, 5},
+		{`let h = {"list": [1, 2]}; h.list[1] = 7; h["list"][1]`, 7},
+		{`let h = {"a": {"n": 1}}; let other = h.a; other.n -= 3; h.a.n`, -2},
+		{"let x = 0; let y = 0; x = y = 4; x + y", 8},
+		{"let calls = 0; let arr = [0, 0]; let i = fn() { calls += 1; 1 }; arr[i()] += 5; calls * 10 + arr[1]", 15},
+		{`let calls = 0; let h = {"n": 1}; let get = fn() { calls += 1; h }; get().n += 1; calls * 10 + h.n`, 12},
+		{"let arr = [1, 2, 3]; arr[-1] = 10; arr[2]", 10},
+		{"let arr = [1, 2, 3]; arr[-3] += 5; arr[0]", 6},
+		{"let arr = [1]; arr[5] = 1", "index 5 out of range for array of length 1"},
+		{"let arr = [1]; arr[-2] = 1", "index -2 out of range for array of length 1"},
+		{`let s = "abc"; s[0] = "x"`, "index assignment not supported: STRING"},
+		{"y += 1", "identifier not found: y"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			errObj, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("no error object returned. got=%T(%+v)", evaluated, evaluated)
+				continue
+			}
+			if errObj.Message != expected {
+				t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+			}
+		}
+	}
+}
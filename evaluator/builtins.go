@@ -1,13 +1,13 @@
 package evaluator
 
 import (
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
-	"os"
-	"path/filepath"
-	"strings"
+	"unicode/utf8"
 
 	"github.com/jumballaya/servo/object"
 )
@@ -23,6 +23,8 @@ var builtins = map[string]*object.Builtin{
 			case *object.Array:
 				return &object.Integer{Value: int64(len(arg.Elements))}
 			case *object.String:
+				return &object.Integer{Value: int64(utf8.RuneCountInString(arg.Value))}
+			case *object.Bytes:
 				return &object.Integer{Value: int64(len(arg.Value))}
 			default:
 				return newError("argument to `len` not supported, got %s", args[0].Type())
@@ -43,9 +45,9 @@ var builtins = map[string]*object.Builtin{
 				}
 				return NULL
 			case *object.String:
-				str := args[0].(*object.String)
-				if len(str.Value) > 0 {
-					return &object.String{Value: strings.Split(str.Value, "")[0]}
+				str := []rune(args[0].(*object.String).Value)
+				if len(str) > 0 {
+					return &object.String{Value: string(str[0])}
 				}
 				return NULL
 			default:
@@ -67,10 +69,9 @@ var builtins = map[string]*object.Builtin{
 				}
 				return NULL
 			case *object.String:
-				str := args[0].(*object.String)
-				if len(str.Value) > 0 {
-					val := strings.Split(str.Value, "")
-					return &object.String{Value: val[len(val)-1]}
+				str := []rune(args[0].(*object.String).Value)
+				if len(str) > 0 {
+					return &object.String{Value: string(str[len(str)-1])}
 				}
 				return NULL
 			default:
@@ -95,10 +96,9 @@ var builtins = map[string]*object.Builtin{
 				}
 				return NULL
 			case *object.String:
-				str := args[0].(*object.String)
-				if len(str.Value) > 0 {
-					val := strings.Split(str.Value, "")
-					return &object.String{Value: strings.Join(val[1:], "")}
+				str := []rune(args[0].(*object.String).Value)
+				if len(str) > 0 {
+					return &object.String{Value: string(str[1:])}
 				}
 				return NULL
 			default:
@@ -145,39 +145,183 @@ var builtins = map[string]*object.Builtin{
 			return NULL
 		},
 	},
-	"file": &object.Builtin{
+	"file": fileBuiltin(""),
+	"slice": &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 2 && len(args) != 3 {
+				return newError("wrong number of arguments. Got: %d. Want: 2 or 3", len(args))
+			}
+
+			bounds := []int64{}
+			for _, arg := range args[1:] {
+				i, ok := arg.(*object.Integer)
+				if !ok {
+					return newError("bounds of `slice` must be INTEGER, got %s", arg.Type())
+				}
+				bounds = append(bounds, i.Value)
+			}
+
+			switch arg := args[0].(type) {
+			case *object.Array:
+				start, end := sliceBounds(int64(len(arg.Elements)), bounds)
+				newElements := make([]object.Object, end-start)
+				copy(newElements, arg.Elements[start:end])
+				return &object.Array{Elements: newElements}
+			case *object.String:
+				str := []rune(arg.Value)
+				start, end := sliceBounds(int64(len(str)), bounds)
+				return &object.String{Value: string(str[start:end])}
+			case *object.Bytes:
+				start, end := sliceBounds(int64(len(arg.Value)), bounds)
+				value := make([]byte, end-start)
+				copy(value, arg.Value[start:end])
+				return &object.Bytes{Value: value}
+			default:
+				return newError("argument to `slice` must be ARRAY, STRING or BYTES, got %s", args[0].Type())
+			}
+		},
+	},
+	"Regex": &object.Builtin{
+		Fn: newRegex,
+	},
+	"Error": &object.Builtin{
+		Fn: newErrorValue,
+	},
+	"bytes": &object.Builtin{
 		Fn: func(args ...object.Object) object.Object {
 			if len(args) != 1 {
 				return newError("wrong number of arguments. Got: %d. Want: 1", len(args))
 			}
 
-			if args[0].Type() != object.STRING_OBJ {
-				return newError("argument to `file` must be STRING, got %s", args[0].Type())
+			switch arg := args[0].(type) {
+			case *object.String:
+				return &object.Bytes{Value: []byte(arg.Value)}
+			case *object.Bytes:
+				value := make([]byte, len(arg.Value))
+				copy(value, arg.Value)
+				return &object.Bytes{Value: value}
+			case *object.Array:
+				value := make([]byte, len(arg.Elements))
+				for i, el := range arg.Elements {
+					b, ok := el.(*object.Integer)
+					if !ok || b.Value < 0 || b.Value > 255 {
+						return newError("array passed to `bytes` must only hold integers from 0 to 255, got %s", el.Inspect())
+					}
+					value[i] = byte(b.Value)
+				}
+				return &object.Bytes{Value: value}
+			default:
+				return newError("argument to `bytes` must be STRING, BYTES or ARRAY, got %s", args[0].Type())
+			}
+		},
+	},
+	"string": &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments. Got: %d. Want: 1", len(args))
 			}
 
-			requiredFile := args[0].Inspect()
-			currentFile := os.Args[1]
-			currentDir := "./" + strings.Join(strings.Split(currentFile, "/")[:1], "/")
+			switch arg := args[0].(type) {
+			case *object.String:
+				return arg
+			case *object.Bytes:
+				return &object.String{Value: string(arg.Value)}
+			default:
+				return &object.String{Value: arg.Inspect()}
+			}
+		},
+	},
+	"to_hex": &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments. Got: %d. Want: 1", len(args))
+			}
 
-			dir, err := filepath.Abs(currentDir + "/" + requiredFile)
-			if err != nil {
-				fmt.Println(err.Error())
-				return newError(err.Error())
+			b, ok := args[0].(*object.Bytes)
+			if !ok {
+				return newError("argument to `to_hex` must be BYTES, got %s", args[0].Type())
+			}
+			return &object.String{Value: hex.EncodeToString(b.Value)}
+		},
+	},
+	"from_hex": &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments. Got: %d. Want: 1", len(args))
 			}
 
-			file, err := ioutil.ReadFile(dir)
+			str, ok := args[0].(*object.String)
+			if !ok {
+				return newError("argument to `from_hex` must be STRING, got %s", args[0].Type())
+			}
+			value, err := hex.DecodeString(str.Value)
 			if err != nil {
-				fmt.Println(err.Error())
-				return newError(err.Error())
+				return newError("could not decode hex: %s", err)
+			}
+			return &object.Bytes{Value: value}
+		},
+	},
+	"to_base64": &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments. Got: %d. Want: 1", len(args))
 			}
 
-			return &object.String{Value: string(file[:])}
+			b, ok := args[0].(*object.Bytes)
+			if !ok {
+				return newError("argument to `to_base64` must be BYTES, got %s", args[0].Type())
+			}
+			return &object.String{Value: base64.StdEncoding.EncodeToString(b.Value)}
 		},
 	},
+	"from_base64": &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments. Got: %d. Want: 1", len(args))
+			}
+
+			str, ok := args[0].(*object.String)
+			if !ok {
+				return newError("argument to `from_base64` must be STRING, got %s", args[0].Type())
+			}
+			value, err := base64.StdEncoding.DecodeString(str.Value)
+			if err != nil {
+				return newError("could not decode base64: %s", err)
+			}
+			return &object.Bytes{Value: value}
+		},
+	},
+}
+
+// Slice Bounds turns the start and optional end of a slice into bounds that are safe to use
+// on a sequence of the given length. Negative bounds count back from the end and anything
+// out of range is clamped.
+func sliceBounds(length int64, bounds []int64) (int64, int64) {
+	clamp := func(i int64) int64 {
+		if i < 0 {
+			i += length
+		}
+		if i < 0 {
+			return 0
+		}
+		if i > length {
+			return length
+		}
+		return i
+	}
+
+	start, end := clamp(bounds[0]), length
+	if len(bounds) > 1 {
+		end = clamp(bounds[1])
+	}
+	if end < start {
+		end = start
+	}
+	return start, end
 }
 
 func getBuiltin(name string, env *object.Environment) (*object.Builtin, bool) {
-	if env.Silent && name == "log" {
+	if env.IsSilent() && name == "log" {
 		return &object.Builtin{
 			Fn: func(args ...object.Object) object.Object {
 				return NULL
@@ -185,9 +329,57 @@ func getBuiltin(name string, env *object.Environment) (*object.Builtin, bool) {
 		}, true
 	}
 
+	// Relative paths in `file` are read from the file the code using it is in
+	if name == "file" {
+		return fileBuiltin(env.File), true
+	}
+
 	if builtin, ok := builtins[name]; ok {
 		return builtin, true
 	}
 
 	return builtins[name], false
 }
+
+// File Builtin creates the `file` builtin for code in the file `from`, relative paths are read
+// from the directory that file is in
+func fileBuiltin(from string) *object.Builtin {
+	return &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			if len(args) != 1 && len(args) != 2 {
+				return newError("wrong number of arguments. Got: %d. Want: 1 or 2", len(args))
+			}
+
+			if args[0].Type() != object.STRING_OBJ {
+				return newError("argument to `file` must be STRING, got %s", args[0].Type())
+			}
+
+			// file(path, "bytes") returns the raw contents instead of a string
+			asBytes := false
+			if len(args) == 2 {
+				mode, ok := args[1].(*object.String)
+				if !ok || (mode.Value != "bytes" && mode.Value != "string") {
+					return newError("second argument to `file` must be \"bytes\" or \"string\", got %s", args[1].Inspect())
+				}
+				asBytes = mode.Value == "bytes"
+			}
+
+			dir, err := resolvePath(args[0].Inspect(), from)
+			if err != nil {
+				fmt.Println(err.Error())
+				return newError("%s", err.Error())
+			}
+
+			file, err := ioutil.ReadFile(dir)
+			if err != nil {
+				fmt.Println(err.Error())
+				return newError("%s", err.Error())
+			}
+
+			if asBytes {
+				return &object.Bytes{Value: file}
+			}
+			return &object.String{Value: string(file[:])}
+		},
+	}
+}
@@ -68,3 +68,71 @@ func TestBuiltinFunctions(t *testing.T) {
 		}
 	}
 }
+
+func TestStringBuiltinsAreCharacterBased(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`len("héllo")`, 5},
+		{`len("日本語")`, 3},
+		{`len("👋 hi")`, 4},
+		{`first("日本語")`, "日"},
+		{`last("日本語")`, "語"},
+		{`rest("日本語")`, "本語"},
+		{`rest("é")`, ""},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			testStringObject(t, evaluated, expected)
+		}
+	}
+}
+
+func TestBytesBuiltins(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`len(b"h\xffi")`, 3},
+		{`len(bytes("é"))`, 2},
+		{`bytes("hi")`, []byte("hi")},
+		{`bytes([104, 105])`, []byte("hi")},
+		{`bytes([256])`, "array passed to `bytes` must only hold integers from 0 to 255, got 256"},
+		{`string(b"hi")`, "hi"},
+		{`to_hex(b"\xde\xad")`, "dead"},
+		{`from_hex("beef")`, []byte("\xbe\xef")},
+		{`from_hex("xyz")`, "could not decode hex: encoding/hex: invalid byte: U+0078 'x'"},
+		{`to_base64(b"hello")`, "aGVsbG8="},
+		{`from_base64("aGVsbG8=")`, []byte("hello")},
+		{`slice(b"hello", 1, 3)`, []byte("el")},
+		{`slice(b"hello", -3)`, []byte("llo")},
+		{`slice("héllo", 1, 10)`, "éllo"},
+		{`len(slice([1, 2, 3], 0, 2))`, 2},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case []byte:
+			testBytesObject(t, evaluated, string(expected))
+		case string:
+			if errObj, ok := evaluated.(*object.Error); ok {
+				if errObj.Message != expected {
+					t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+				}
+				continue
+			}
+			testStringObject(t, evaluated, expected)
+		}
+	}
+}
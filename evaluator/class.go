@@ -1,11 +1,8 @@
 package evaluator
 
 import (
-	"fmt"
-
 	"github.com/jumballaya/servo/ast"
 	"github.com/jumballaya/servo/object"
-	"github.com/jumballaya/servo/token"
 )
 
 // Eval Class Literal
@@ -15,12 +12,12 @@ func evalClassLiteral(node *ast.ClassLiteral, env *object.Environment) object.Ob
 	if node.Parent != "" {
 		pObj, ok := env.Get(node.Parent)
 		if !ok {
-			return newError(fmt.Sprintf("could not find parent %s of declared class %s", node.Parent, node.Name))
+			return newError("could not find parent %s of declared class %s", node.Parent, node.Name)
 		}
 
 		parent, ok = pObj.(*object.Class)
 		if !ok {
-			return newError(fmt.Sprintf("parent of class %s is not an object.Class", node.Name))
+			return newError("parent of class %s is not an object.Class", node.Name)
 		}
 	}
 
@@ -29,6 +26,7 @@ func evalClassLiteral(node *ast.ClassLiteral, env *object.Environment) object.Ob
 		Parent:  parent,
 		Fields:  node.Fields,
 		Methods: make(map[string]object.ClassMethod),
+		Env:     env,
 	}
 
 	for _, field := range node.Fields {
@@ -65,7 +63,8 @@ func evalNewClassInstance(node *ast.InstanceLiteral, env *object.Environment) ob
 		return newError("cannot create an instance of a class that doesn't exist")
 	}
 
-	newEnv := object.NewEnclosedEnvironment(env)
+	// Methods see the names of the module that declared the class, wherever `new` is called
+	newEnv := object.NewEnclosedEnvironment(classObj.Env)
 	instance := &object.Instance{Class: classObj, Fields: newEnv}
 	if instance.Class.Parent != nil {
 		var parent *object.Class
@@ -89,14 +88,11 @@ func evalNewClassInstance(node *ast.InstanceLiteral, env *object.Environment) ob
 		case *ast.FunctionLiteral:
 			if name == "constructor" {
 				if f.Value != nil {
-					function, _ := f.Value.(*ast.FunctionLiteral)
-					callExp := &ast.CallExpression{
-						Token:     token.Token{Type: token.LPAREN, Literal: "("},
-						Function:  function,
-						Arguments: node.Arguments,
+					// The arguments are evaluated where `new` is called
+					constructor := Eval(f.Value, newEnv)
+					if result := callFunction(constructor, node.Arguments, env); isError(result) {
+						return result
 					}
-					// Fix this
-					evalCallFunction(callExp, newEnv)
 				}
 			} else {
 				evaluated := Eval(f, newEnv)
@@ -106,3 +106,60 @@ g.welcome();
 		t.Fatalf("class fields not being set in constructor. Expected: %s, Got: %s", "Hello Name", s.Value)
 	}
 }
+
+func TestClassFieldReassignment(t *testing.T) {
+	input := `
+class Paint {
+	let constructor = fn(color) {
+		this.color = color;
+	}
+	let change = fn(color) {
+		this.color = color;
+	};
+};
+let p = new Paint("red");
+p.change("blue");
+p.color;`
+
+	evaluated := testEval(input)
+	s, ok := evaluated.(*object.String)
+	if !ok {
+		t.Fatalf("object from p.color is not string. got=%T (%+v)", evaluated, evaluated)
+	}
+
+	if s.Value != "blue" {
+		t.Fatalf("class field not updated. Expected: %s, Got: %s", "blue", s.Value)
+	}
+}
+
+func TestClassConstructorErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			"class P { let constructor = fn(a, b) { this.a = a; } }; new P(1)",
+			"wrong number of arguments to constructor. Got: 1. Want: 2",
+		},
+		{
+			"class P { let constructor = fn(a) { throw 'bad ' + a; } }; new P(1)",
+			"bad 1",
+		},
+		{
+			"class P { let constructor = fn(a) { throw 'bad'; } }; try { new P(1) } catch (e) { throw 'caught ' + e.message }",
+			"caught bad",
+		},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned for %q. got=%T(%+v)", tt.input, evaluated, evaluated)
+			continue
+		}
+		if errObj.Message != tt.expected {
+			t.Errorf("wrong error message. expected=%q, got=%q", tt.expected, errObj.Message)
+		}
+	}
+}
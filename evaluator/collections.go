@@ -19,6 +19,10 @@ func evalIndexExpression(left, index object.Object) object.Object {
 	switch {
 	case left.Type() == object.ARRAY_OBJ && index.Type() == object.INTEGER_OBJ:
 		return evalArrayIndexExpression(left, index)
+	case left.Type() == object.STRING_OBJ && index.Type() == object.INTEGER_OBJ:
+		return evalStringIndexExpression(left, index)
+	case left.Type() == object.BYTES_OBJ && index.Type() == object.INTEGER_OBJ:
+		return evalBytesIndexExpression(left, index)
 	case left.Type() == object.HASH_OBJ:
 		return evalHashIndexExpression(left, index)
 	default:
@@ -29,16 +33,146 @@ func evalIndexExpression(left, index object.Object) object.Object {
 // Eval Array Index Expression
 func evalArrayIndexExpression(array, index object.Object) object.Object {
 	arrayObject := array.(*object.Array)
-	id := index.(*object.Integer).Value
-	max := int64(len(arrayObject.Elements) - 1)
-
-	if id < 0 || id > max {
+	id, ok := normalizeIndex(index.(*object.Integer).Value, len(arrayObject.Elements))
+	if !ok {
 		return NULL
 	}
 
 	return arrayObject.Elements[id]
 }
 
+// Eval String Index Expression returns the character at the index, strings are indexed by rune
+func evalStringIndexExpression(str, index object.Object) object.Object {
+	runes := []rune(str.(*object.String).Value)
+	id, ok := normalizeIndex(index.(*object.Integer).Value, len(runes))
+	if !ok {
+		return NULL
+	}
+
+	return &object.String{Value: string(runes[id])}
+}
+
+// Eval Bytes Index Expression returns the byte at the index as a single byte Bytes object
+func evalBytesIndexExpression(bytes, index object.Object) object.Object {
+	bytesObject := bytes.(*object.Bytes)
+	id, ok := normalizeIndex(index.(*object.Integer).Value, len(bytesObject.Value))
+	if !ok {
+		return NULL
+	}
+
+	return &object.Bytes{Value: []byte{bytesObject.Value[id]}}
+}
+
+// Normalize Index counts negative indexes from the end, so -1 is the last element. It returns
+// false when the index is out of range for the length.
+func normalizeIndex(id int64, length int) (int64, bool) {
+	if id < 0 {
+		id += int64(length)
+	}
+	return id, id >= 0 && id < int64(length)
+}
+
+// Eval Slice Expression slices an array, string or bytes with Python's rules: bounds can be
+// left out or negative, bounds out of range are clamped and a negative step walks backwards.
+// e.g. `arr[1:3]`, `str[::-1]`
+func evalSliceExpression(node *ast.SliceExpression, env *object.Environment) object.Object {
+	left := evalChain(node.Left, env)
+	if isError(left) || left == shortCircuited {
+		return left
+	}
+
+	var bounds [3]*int64
+	for i, exp := range []ast.Expression{node.Start, node.End, node.Step} {
+		if exp == nil {
+			continue
+		}
+		val := Eval(exp, env)
+		if isError(val) {
+			return val
+		}
+		integer, ok := val.(*object.Integer)
+		if !ok {
+			return newError("slice bounds must be INTEGER, got %s", val.Type())
+		}
+		bounds[i] = &integer.Value
+	}
+
+	step := int64(1)
+	if bounds[2] != nil {
+		step = *bounds[2]
+	}
+	if step == 0 {
+		return newError("slice step cannot be zero")
+	}
+
+	switch left := left.(type) {
+	case *object.Array:
+		indices := sliceIndices(int64(len(left.Elements)), bounds[0], bounds[1], step)
+		elements := make([]object.Object, len(indices))
+		for i, id := range indices {
+			elements[i] = left.Elements[id]
+		}
+		return &object.Array{Elements: elements}
+	case *object.String:
+		str := []rune(left.Value)
+		indices := sliceIndices(int64(len(str)), bounds[0], bounds[1], step)
+		runes := make([]rune, len(indices))
+		for i, id := range indices {
+			runes[i] = str[id]
+		}
+		return &object.String{Value: string(runes)}
+	case *object.Bytes:
+		indices := sliceIndices(int64(len(left.Value)), bounds[0], bounds[1], step)
+		value := make([]byte, len(indices))
+		for i, id := range indices {
+			value[i] = left.Value[id]
+		}
+		return &object.Bytes{Value: value}
+	default:
+		return newError("slice operator not supported: %s", left.Type())
+	}
+}
+
+// Slice Indices lists the indices a slice picks out of a sequence of the given length. A nil
+// start or end means the slice runs from or to the edge the step is moving away from or towards.
+func sliceIndices(length int64, start, end *int64, step int64) []int64 {
+	// With a negative step the bounds are clamped to [-1, length-1] since -1 means before
+	// the first element
+	lower, upper := int64(0), length
+	if step < 0 {
+		lower, upper = -1, length-1
+	}
+
+	clamp := func(bound *int64, def int64) int64 {
+		if bound == nil {
+			return def
+		}
+		i := *bound
+		if i < 0 {
+			i += length
+		}
+		if i < lower {
+			return lower
+		}
+		if i > upper {
+			return upper
+		}
+		return i
+	}
+
+	var indices []int64
+	if step > 0 {
+		for i := clamp(start, lower); i < clamp(end, upper); i += step {
+			indices = append(indices, i)
+		}
+	} else {
+		for i := clamp(start, upper); i > clamp(end, lower); i += step {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
 // Eval Hash Literal
 func evalHashLiteral(node *ast.HashLiteral, env *object.Environment) object.Object {
 	pairs := make(map[object.HashKey]object.HashPair)
@@ -85,11 +219,16 @@ func evalHashIndexExpression(hash, index object.Object) object.Object {
 
 // Eval Attribute Expression
 func evalAttributeExpression(node *ast.AttributeExpression, env *object.Environment) object.Object {
-	left := Eval(node.Left, env)
-	if isError(left) {
+	left := evalChain(node.Left, env)
+	if isError(left) || left == shortCircuited {
 		return left
 	}
 
+	// Optional chaining, `foo?.bar` is null when foo is null, along with the rest of the chain
+	if node.Optional && left == NULL {
+		return shortCircuited
+	}
+
 	var Left object.Object
 
 	str, ok := node.Left.(*ast.Identifier)
@@ -102,6 +241,19 @@ func evalAttributeExpression(node *ast.AttributeExpression, env *object.Environm
 		Left = left
 	}
 
+	if re, ok := Left.(*object.Regex); ok {
+		return bindRegexMethod(re, node.Index.Value)
+	}
+
+	if ev, ok := Left.(*object.ErrorValue); ok {
+		return errorValueField(ev, node.Index.Value)
+	}
+
+	// Dot notation reads string keys from a hash, e.g. `config.port` is `config["port"]`
+	if hash, ok := Left.(*object.Hash); ok {
+		return evalHashIndexExpression(hash, &object.String{Value: node.Index.Value})
+	}
+
 	instance, ok := Left.(*object.Instance)
 	if !ok {
 		return newError("left hand side not an instance, type: %T", left)
@@ -68,6 +68,14 @@ func TestArrayIndexExpressions(t *testing.T) {
 		},
 		{
 			"[1, 2, 3][-1]",
+			3,
+		},
+		{
+			"[1, 2, 3][-3]",
+			1,
+		},
+		{
+			"[1, 2, 3][-4]",
 			nil,
 		},
 	}
@@ -83,6 +91,91 @@ func TestArrayIndexExpressions(t *testing.T) {
 	}
 }
 
+func TestStringAndBytesIndexExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`"abc"[0]`, "a"},
+		{`"abc"[-1]`, "c"},
+		{`"héllo"[1]`, "é"},
+		{`"héllo"[-4]`, "é"},
+		{`"abc"[3]`, nil},
+		{`"abc"[-4]`, nil},
+		{`b"abc"[1]`, []byte("b")},
+		{`b"abc"[-1]`, []byte("c")},
+		{`b"abc"[-4]`, nil},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case string:
+			testStringObject(t, evaluated, expected)
+		case []byte:
+			testBytesObject(t, evaluated, string(expected))
+		default:
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestSliceExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"[1, 2, 3, 4, 5][1:3]", []int64{2, 3}},
+		{"[1, 2, 3, 4, 5][:2]", []int64{1, 2}},
+		{"[1, 2, 3, 4, 5][2:]", []int64{3, 4, 5}},
+		{"[1, 2, 3, 4, 5][-2:]", []int64{4, 5}},
+		{"[1, 2, 3, 4, 5][:-1]", []int64{1, 2, 3, 4}},
+		{"[1, 2, 3, 4, 5][::2]", []int64{1, 3, 5}},
+		{"[1, 2, 3, 4, 5][1::2]", []int64{2, 4}},
+		{"[1, 2, 3, 4, 5][::-1]", []int64{5, 4, 3, 2, 1}},
+		{"[1, 2, 3, 4, 5][3:0:-1]", []int64{4, 3, 2}},
+		{"[1, 2, 3, 4, 5][-10:10]", []int64{1, 2, 3, 4, 5}},
+		{"[1, 2, 3, 4, 5][4:2]", []int64{}},
+		{"[1, 2, 3, 4, 5][10:]", []int64{}},
+		{"'hello'[1:3]", "el"},
+		{"'héllo'[:2]", "hé"},
+		{"'hello'[::-1]", "olleh"},
+		{"'hello'[-3:]", "llo"},
+		{"[1, 2][::0]", "slice step cannot be zero"},
+		{"[1, 2]['a':]", "slice bounds must be INTEGER, got STRING"},
+		{"5[1:]", "slice operator not supported: INTEGER"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case []int64:
+			array, ok := evaluated.(*object.Array)
+			if !ok {
+				t.Errorf("object is not Array. got=%T (%+v)", evaluated, evaluated)
+				continue
+			}
+			if len(array.Elements) != len(expected) {
+				t.Errorf("wrong number of elements. want=%d, got=%d", len(expected), len(array.Elements))
+				continue
+			}
+			for i, el := range expected {
+				testIntegerObject(t, array.Elements[i], el)
+			}
+		case string:
+			if errObj, ok := evaluated.(*object.Error); ok {
+				if errObj.Message != expected {
+					t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+				}
+				continue
+			}
+			testStringObject(t, evaluated, expected)
+		}
+	}
+}
+
 func TestHashLiterals(t *testing.T) {
 	input := `let two = "two";
 	{
@@ -168,3 +261,40 @@ func TestHashIndexExpressions(t *testing.T) {
 		}
 	}
 }
+
+func TestHashDotNotation(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`let config = {"port": 8080}; config.port`, 8080},
+		{`let config = {"port": 8080}; config.host`, nil},
+		{`let req = {"body": {"user": {"name": "ada"}}}; req.body.user.name`, "ada"},
+		{`let config = {}; config.port = 80; config["port"]`, 80},
+		{`let config = {"port": 80}; config.port = 81; config.port`, 81},
+		{`let req = {"body": {"user": {"name": "ada"}}}; req.body.user.name = "grace"; req.body.user.name`, "grace"},
+		{`let h = {"n": 1}; h.n += 2; h.n`, 3},
+		{`let a = {}; let b = a; b.x = 1; a.x`, 1},
+		{`let h = {"a": {}}; h.a.b.c = 1`, "cannot set attribute c on NULL"},
+		{`let n = 5; n.x = 1`, "cannot set attribute x on INTEGER"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			if errObj, ok := evaluated.(*object.Error); ok {
+				if errObj.Message != expected {
+					t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+				}
+				continue
+			}
+			testStringObject(t, evaluated, expected)
+		case nil:
+			testNullObject(t, evaluated)
+		}
+	}
+}
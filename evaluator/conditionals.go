@@ -22,6 +22,19 @@ func evalIfExpression(ie *ast.IfExpression, env *object.Environment) object.Obje
 	}
 }
 
+// Eval Ternary Expression evaluates only the branch picked by the condition
+func evalTernaryExpression(te *ast.TernaryExpression, env *object.Environment) object.Object {
+	condition := Eval(te.Condition, env)
+	if isError(condition) {
+		return condition
+	}
+
+	if isTruthy(condition) {
+		return Eval(te.Consequence, env)
+	}
+	return Eval(te.Alternative, env)
+}
+
 // Is Truthy determines if a non-boolean value should act as a literal true or false
 func isTruthy(obj object.Object) bool {
 	// Check to see if a string is empty or not
@@ -33,6 +46,11 @@ func isTruthy(obj object.Object) bool {
 		return false
 	}
 
+	// Check to see if a byte buffer is empty or not
+	if b, ok := obj.(*object.Bytes); ok {
+		return len(b.Value) > 0
+	}
+
 	// Check if a number is 0 or not
 	num, ok := obj.(*object.Integer)
 	if ok {
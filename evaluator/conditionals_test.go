@@ -1,6 +1,10 @@
 package evaluator
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/jumballaya/servo/object"
+)
 
 func TestIfElseExpressions(t *testing.T) {
 	tests := []struct {
@@ -31,3 +35,58 @@ func TestIfElseExpressions(t *testing.T) {
 		}
 	}
 }
+
+func TestConditionalOperators(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"true ? 1 : 2", 1},
+		{"false ? 1 : 2", 2},
+		{"null ? 1 : 2", 2},
+		{"let x = 5; x > 3 ? x * 2 : x", 10},
+		{"let n = 0; n == 0 ? 1 : n == 1 ? 2 : 3", 1},
+		{"let n = 1; n == 0 ? 1 : n == 1 ? 2 : 3", 2},
+		{"let n = 7; n == 0 ? 1 : n == 1 ? 2 : 3", 3},
+		{"true ? 1 : undefinedThing", 1},
+		{"null ?? 5", 5},
+		{"3 ?? 5", 3},
+		{"false ?? 5", false},
+		{"0 ?? 5", 0},
+		{"null ?? null ?? 7", 7},
+		{"1 ?? undefinedThing", 1},
+		{`let req = {"body": {"user": {"name": 1}}}; req?.body?.user?.name`, 1},
+		{`let req = {"body": null}; req?.body?.user`, nil},
+		{`let req = null; req?.body`, nil},
+		{`let req = {}; req.body?.user ?? 9`, 9},
+		{"let req = null; req.body", "left hand side not an instance, type: *object.Null"},
+		{"let n = null; n?.x.y", nil},
+		{"let n = null; n?.f()", nil},
+		{"let n = null; n?.x[0].y()", nil},
+		{"let n = null; n?.x[1:]", nil},
+		{"let n = null; let v = n?.x; v == null", true},
+		{`let n = {"x": null}; n?.x.y`, "left hand side not an instance, type: *object.Null"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		case string:
+			errObj, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("no error object returned. got=%T(%+v)", evaluated, evaluated)
+				continue
+			}
+			if errObj.Message != expected {
+				t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+			}
+		case nil:
+			testNullObject(t, evaluated)
+		}
+	}
+}
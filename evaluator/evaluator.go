@@ -10,6 +10,29 @@ import (
 // Eval is the evaluator function that recursively runs, evaluating the program
 // and its statements.
 func Eval(node ast.Node, env *object.Environment) object.Object {
+	// A chain skipped by `?.` is null once the whole chain is done
+	if result := evalChain(node, env); result != shortCircuited {
+		return result
+	}
+	return NULL
+}
+
+// Eval Chain evaluates a node like Eval, except that a short circuited optional chain gives
+// back shortCircuited. Attribute, index and call expressions evaluate their left side with it
+// so `n?.x.y` and `n?.f()` skip everything after the `?.` when n is null.
+func evalChain(node ast.Node, env *object.Environment) object.Object {
+	result := evalNode(node, env)
+
+	// Errors are tagged with the position of the innermost node that produced them
+	if err, ok := result.(*object.Error); ok && node != nil && !err.Pos.IsValid() {
+		err.Pos = node.Pos()
+	}
+
+	return result
+}
+
+// Eval Node dispatches the node to its evaluation function
+func evalNode(node ast.Node, env *object.Environment) object.Object {
 	switch node := node.(type) {
 
 	// Main Program
@@ -40,6 +63,14 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 	case *ast.StringLiteral:
 		return evalStringLiteral(node, env)
 
+	// Bytes
+	case *ast.BytesLiteral:
+		return evalBytesLiteral(node, env)
+
+	// Template String
+	case *ast.TemplateLiteral:
+		return evalTemplateLiteral(node, env)
+
 	// Array
 	case *ast.ArrayLiteral:
 		return evalArrayLiteral(node, env)
@@ -62,8 +93,8 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 
 	// Index Expression
 	case *ast.IndexExpression:
-		left := Eval(node.Left, env)
-		if isError(left) {
+		left := evalChain(node.Left, env)
+		if isError(left) || left == shortCircuited {
 			return left
 		}
 
@@ -73,6 +104,12 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		}
 		return evalIndexExpression(left, index)
 
+	case *ast.SliceExpression:
+		return evalSliceExpression(node, env)
+
+	case *ast.SpreadExpression:
+		return newError("spread is only allowed in call arguments and array literals")
+
 	// Prefix
 	case *ast.PrefixExpression:
 		right := Eval(node.Right, env)
@@ -83,6 +120,10 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 
 	// Infix
 	case *ast.InfixExpression:
+		if node.Operator == "&&" || node.Operator == "||" || node.Operator == "??" {
+			return evalLogicalExpression(node, env)
+		}
+
 		left := Eval(node.Left, env)
 		if isError(left) {
 			return left
@@ -94,6 +135,9 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		}
 		return evalInfixExpression(node.Operator, left, right)
 
+	case *ast.TernaryExpression:
+		return evalTernaryExpression(node, env)
+
 	// Block
 	case *ast.BlockStatement:
 		return evalBlockStatement(node, env)
@@ -101,6 +145,27 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 	// If
 	case *ast.IfExpression:
 		return evalIfExpression(node, env)
+	case *ast.MatchExpression:
+		return evalMatchExpression(node, env)
+
+	// For
+	case *ast.ForStatement:
+		return evalForStatement(node, env)
+
+	case *ast.WhileStatement:
+		return evalWhileStatement(node, env)
+
+	case *ast.TryStatement:
+		return evalTryStatement(node, env)
+
+	case *ast.ThrowStatement:
+		return evalThrowStatement(node, env)
+
+	case *ast.BreakStatement:
+		return BREAK
+
+	case *ast.ContinueStatement:
+		return CONTINUE
 
 	// Return
 	case *ast.ReturnStatement:
@@ -109,6 +174,12 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 	// Let
 	case *ast.LetStatement:
 		return evalLetStatement(node, env)
+	case *ast.FunctionStatement:
+		return evalFunctionStatement(node, env)
+
+	// Export, only changes what other modules can import
+	case *ast.ExportStatement:
+		return Eval(node.Statement, env)
 
 	// Assignment
 	case *ast.AssignExpression:
@@ -141,6 +212,7 @@ func newError(format string, a ...interface{}) *object.Error {
 func evalProgram(program *ast.Program, env *object.Environment) object.Object {
 	var result object.Object
 
+	hoistFunctions(program.Statements, env)
 	for _, stmt := range program.Statements {
 		result = Eval(stmt, env)
 
@@ -175,6 +247,22 @@ func evalExpressions(exps []ast.Expression, env *object.Environment) []object.Ob
 	var result []object.Object
 
 	for _, e := range exps {
+		// Spread expressions add every element of an array
+		if spread, ok := e.(*ast.SpreadExpression); ok {
+			evaluated := Eval(spread.Value, env)
+			if isError(evaluated) {
+				return []object.Object{evaluated}
+			}
+			array, ok := evaluated.(*object.Array)
+			if !ok {
+				err := newError("cannot spread %s", evaluated.Type())
+				err.Pos = spread.Pos()
+				return []object.Object{err}
+			}
+			result = append(result, array.Elements...)
+			continue
+		}
+
 		evaluated := Eval(e, env)
 		if isError(evaluated) {
 			return []object.Object{evaluated}
@@ -190,12 +278,13 @@ func evalExpressions(exps []ast.Expression, env *object.Environment) []object.Ob
 func evalBlockStatement(block *ast.BlockStatement, env *object.Environment) object.Object {
 	var result object.Object
 
+	hoistFunctions(block.Statements, env)
 	for _, stmt := range block.Statements {
 		result = Eval(stmt, env)
 
 		if result != nil {
 			rt := result.Type()
-			if rt == object.RETURN_VALUE_OBJ || rt == object.ERROR_OBJ {
+			if rt == object.RETURN_VALUE_OBJ || rt == object.ERROR_OBJ || rt == object.BREAK_OBJ || rt == object.CONTINUE_OBJ {
 				return result
 			}
 		}
@@ -92,6 +92,31 @@ if (10 > 1) {
 	}
 }
 
+func TestErrorPositions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"5 + true;", "Error: 1:3: type mismatch: INTEGER + BOOLEAN"},
+		{"let x = 1;\nlet y = foobar;", "Error: 2:9: identifier not found: foobar"},
+		{"let f = fn(x) {\n  x - \"a\"\n};\nf(1);", "Error: 2:5: type mismatch: INTEGER - STRING"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned. got=%T(%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if errObj.Inspect() != tt.expected {
+			t.Errorf("wrong error. expected: %q. Got: %q", tt.expected, errObj.Inspect())
+		}
+	}
+}
+
 func testEval(input string) object.Object {
 	l := lexer.New(input)
 	p := parser.New(l)
@@ -154,3 +179,33 @@ func testNullObject(t *testing.T, obj object.Object) bool {
 	}
 	return true
 }
+
+func testStringObject(t *testing.T, obj object.Object, expected string) bool {
+	t.Helper()
+	result, ok := obj.(*object.String)
+	if !ok {
+		t.Errorf("object is not String. got=%T (%+v)", obj, obj)
+		return false
+	}
+	if result.Value != expected {
+		t.Errorf("object has wrong value. got=%q, want=%q",
+			result.Value, expected)
+		return false
+	}
+	return true
+}
+
+func testBytesObject(t *testing.T, obj object.Object, expected string) bool {
+	t.Helper()
+	result, ok := obj.(*object.Bytes)
+	if !ok {
+		t.Errorf("object is not Bytes. got=%T (%+v)", obj, obj)
+		return false
+	}
+	if string(result.Value) != expected {
+		t.Errorf("object has wrong value. got=%q, want=%q",
+			result.Value, expected)
+		return false
+	}
+	return true
+}
@@ -1,6 +1,9 @@
 package evaluator
 
 import (
+	"sort"
+	"strconv"
+
 	"github.com/jumballaya/servo/ast"
 	"github.com/jumballaya/servo/object"
 )
@@ -9,30 +12,96 @@ import (
 func evalFunctionLiteral(node *ast.FunctionLiteral, env *object.Environment) object.Object {
 	params := node.Parameters
 	body := node.Body
-	return &object.Function{Parameters: params, Env: env, Body: body}
+	return &object.Function{Name: node.Name, Parameters: params, Rest: node.Rest, Env: env, Body: body}
+}
+
+// Eval Function Statement gives back the function its declaration bound, the binding itself
+// already happened when the block's declarations were hoisted
+func evalFunctionStatement(node *ast.FunctionStatement, env *object.Environment) object.Object {
+	if fn, ok := env.Get(node.Name.Value); ok {
+		return fn
+	}
+	return env.Set(node.Name.Value, evalFunctionLiteral(node.Function, env))
+}
+
+// Hoist Functions binds the function declarations of a block before any of its statements
+// run, so they can call each other no matter what order they are declared in
+func hoistFunctions(stmts []ast.Statement, env *object.Environment) {
+	for _, stmt := range stmts {
+		if export, ok := stmt.(*ast.ExportStatement); ok {
+			stmt = export.Statement
+		}
+		if fs, ok := stmt.(*ast.FunctionStatement); ok {
+			env.Set(fs.Name.Value, evalFunctionLiteral(fs.Function, env))
+		}
+	}
 }
 
 // Eval Call Function
 func evalCallFunction(node *ast.CallExpression, env *object.Environment) object.Object {
-	function := Eval(node.Function, env)
-	if isError(function) {
+	function := evalChain(node.Function, env)
+	if isError(function) || function == shortCircuited {
 		return function
 	}
-	args := evalExpressions(node.Arguments, env)
+
+	return callFunction(function, node.Arguments, env)
+}
+
+// Call Function evaluates the arguments of a call in env and calls the function with them
+func callFunction(function object.Object, arguments []ast.Expression, env *object.Environment) object.Object {
+	// The parser puts named arguments after all of the positional ones
+	positional := arguments
+	var named []*ast.NamedArgument
+	for i, arg := range arguments {
+		if n, ok := arg.(*ast.NamedArgument); ok {
+			if named == nil {
+				positional = arguments[:i]
+			}
+			named = append(named, n)
+		}
+	}
+
+	args := evalExpressions(positional, env)
 	if len(args) == 1 && isError(args[0]) {
 		return args[0]
 	}
-	return applyFunction(function, args)
+
+	if len(named) == 0 {
+		return applyFunction(function, args)
+	}
+
+	namedArgs := make(map[string]object.Object, len(named))
+	for _, n := range named {
+		val := Eval(n.Value, env)
+		if isError(val) {
+			return val
+		}
+		namedArgs[n.Name.Value] = val
+	}
+
+	return applyFunctionWithNamed(function, args, namedArgs)
 }
 
 // Apply Function parses the function call expressions
 func applyFunction(fn object.Object, args []object.Object) object.Object {
+	return applyFunctionWithNamed(fn, args, nil)
+}
+
+// Apply Function With Named calls a function with positional and named arguments. Only
+// functions written in Servo take named arguments, builtins don't have parameter names.
+func applyFunctionWithNamed(fn object.Object, args []object.Object, named map[string]object.Object) object.Object {
 	switch fn := fn.(type) {
 	case *object.Function:
-		extendedEnv := extendFunctionEnv(fn, args)
+		extendedEnv, err := extendFunctionEnv(fn, args, named)
+		if err != nil {
+			return err
+		}
 		evaluated := Eval(fn.Body, extendedEnv)
 		return unwrapReturnValue(evaluated)
 	case *object.Builtin:
+		if len(named) > 0 {
+			return newError("builtin functions do not take named arguments")
+		}
 		return fn.Fn(args...)
 	default:
 		return newError("not a function: %s", fn.Type())
@@ -47,12 +116,130 @@ func applyFunction(fn object.Object, args []object.Object) object.Object {
 // `x = 1` and `y = 2` before you can evaluate `x + y` you must bind them to the environment
 // of that block. This function is doing exactly that: binding x to 1 and y to 2 in the local
 // environment.
-func extendFunctionEnv(fn *object.Function, args []object.Object) *object.Environment {
+//
+// Parameters are patterns, so `fn([x, y]) { ... }` destructures its first argument. A
+// parameter with a default can be left out, every other one needs an argument, either by
+// position or by name. Extra positional arguments go into the rest parameter, or are an error
+// if the function doesn't have one.
+func extendFunctionEnv(fn *object.Function, args []object.Object, named map[string]object.Object) (*object.Environment, object.Object) {
 	env := object.NewEnclosedEnvironment(fn.Env)
 
+	if fn.Rest == nil && len(args) > len(fn.Parameters) {
+		return nil, arityError(fn, len(args)+len(named))
+	}
+
+	// A misspelled name would otherwise show up as a missing argument
+	names := make([]string, 0, len(named))
+	for name := range named {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !hasParameter(fn, name) {
+			return nil, newError("%s has no parameter named %s", functionName(fn), name)
+		}
+	}
+
 	for paramId, param := range fn.Parameters {
-		env.Set(param.Value, args[paramId])
+		var arg object.Object
+		if paramId < len(args) {
+			arg = args[paramId]
+		}
+
+		if name := parameterName(param); name != "" {
+			if val, ok := named[name]; ok {
+				if arg != nil {
+					return nil, newError("argument %s to %s given more than once", name, functionName(fn))
+				}
+				arg = val
+			}
+		}
+
+		if arg == nil {
+			if _, ok := param.(*ast.DefaultPattern); !ok {
+				if len(named) == 0 {
+					return nil, arityError(fn, len(args))
+				}
+				return nil, newError("missing argument %s to %s", param.String(), functionName(fn))
+			}
+			arg = NULL
+		}
+
+		if err := bindPattern(param, arg, env); err != nil {
+			return nil, err
+		}
 	}
 
-	return env
+	if fn.Rest != nil {
+		rest := []object.Object{}
+		if len(args) > len(fn.Parameters) {
+			rest = append(rest, args[len(fn.Parameters):]...)
+		}
+		env.Set(fn.Rest.Value, &object.Array{Elements: rest})
+	}
+
+	return env, nil
+}
+
+// Arity Error reports a call with the wrong number of arguments, e.g.
+// `wrong number of arguments to add. Got: 1. Want: 2`
+func arityError(fn *object.Function, got int) *object.Error {
+	required := 0
+	for _, param := range fn.Parameters {
+		if _, ok := param.(*ast.DefaultPattern); !ok {
+			required++
+		}
+	}
+
+	var want string
+	switch {
+	case fn.Rest != nil:
+		want = "at least " + strconv.Itoa(required)
+	case required == len(fn.Parameters):
+		want = strconv.Itoa(required)
+	default:
+		want = strconv.Itoa(required) + " to " + strconv.Itoa(len(fn.Parameters))
+	}
+
+	return newError("wrong number of arguments to %s. Got: %d. Want: %s", functionName(fn), got, want)
+}
+
+// Function Name is the name used for a function in error messages
+func functionName(fn *object.Function) string {
+	if fn.Name == "" {
+		return "anonymous function"
+	}
+	return fn.Name
+}
+
+// Parameter Name is the name a parameter can be passed by, parameters that destructure
+// their argument can't be named
+func parameterName(param ast.Pattern) string {
+	if def, ok := param.(*ast.DefaultPattern); ok {
+		param = def.Target
+	}
+	if ident, ok := param.(*ast.Identifier); ok {
+		return ident.Value
+	}
+	return ""
+}
+
+// Has Parameter checks if a function has a parameter that can be passed by the name
+func hasParameter(fn *object.Function, name string) bool {
+	for _, param := range fn.Parameters {
+		if parameterName(param) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Callback Args trims the arguments a builtin passes to a callback down to the ones the
+// callback has parameters for, so `fn(match) { ... }` can be used where more are given
+func callbackArgs(fn object.Object, args []object.Object) []object.Object {
+	f, ok := fn.(*object.Function)
+	if !ok || f.Rest != nil || len(args) <= len(f.Parameters) {
+		return args
+	}
+	return args[:len(f.Parameters)]
 }
@@ -77,3 +77,125 @@ addTwo(2);`
 
 	testIntegerObject(t, testEval(input), 4)
 }
+
+func TestFunctionArguments(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"let f = fn(x, y = 10) { x + y }; f(1)", 11},
+		{"let f = fn(x, y = 10) { x + y }; f(1, 2)", 3},
+		{"let f = fn(x, y = x * 2) { y }; f(4)", 8},
+		{"let f = fn(first, ...others) { len(others) }; f(1, 2, 3)", 2},
+		{"let f = fn(first, ...others) { len(others) }; f(1)", 0},
+		{"let f = fn(...all) { all[1] }; f(1, 2, 3)", 2},
+		{"let add = fn(a, b, c) { a + b + c }; let args = [1, 2, 3]; add(...args)", 6},
+		{"let add = fn(a, b, c) { a + b + c }; add(1, ...[2, 3])", 6},
+		{"let sum = fn(...n) { n[0] + n[3] }; sum(...[1, 2], ...[3, 4])", 5},
+		{"len([0, ...[1, 2], 3])", 4},
+		{"let listen = fn(host = \"localhost\", port = 80) { port }; listen(port: 8080)", 8080},
+		{"let f = fn(a, b) { a - b }; f(b: 1, a: 5)", 4},
+		{"let f = fn(a, b = 2, c = 3) { a * 100 + b * 10 + c }; f(1, c: 9)", 129},
+		{"let add = fn(x, y) { x + y }; add(1)", "wrong number of arguments to add. Got: 1. Want: 2"},
+		{"let add = fn(x, y) { x + y }; add(1, 2, 3)", "wrong number of arguments to add. Got: 3. Want: 2"},
+		{"let f = fn(x, y = 1) { x }; f()", "wrong number of arguments to f. Got: 0. Want: 1 to 2"},
+		{"let f = fn(x, ...r) { x }; f()", "wrong number of arguments to f. Got: 0. Want: at least 1"},
+		{"fn(x) { x }()", "wrong number of arguments to anonymous function. Got: 0. Want: 1"},
+		{"let f = fn(a, b) { a }; f(b: 1)", "missing argument a to f"},
+		{"let f = fn(a) { a }; f(1, a: 2)", "argument a to f given more than once"},
+		{"let f = fn(a = 1) { a }; f(z: 2)", "f has no parameter named z"},
+		{"let f = fn(x) { x }; f(y: 1)", "f has no parameter named y"},
+		{"let f = fn(x) { x }; f(z: 1, y: 2)", "f has no parameter named y"},
+		{"len(x: [1])", "builtin functions do not take named arguments"},
+		{"let f = fn(a) { a }; f(...5)", "cannot spread INTEGER"},
+		{"let x = ...[1];", "spread is only allowed in call arguments and array literals"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			errObj, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("no error object returned. got=%T(%+v)", evaluated, evaluated)
+				continue
+			}
+			if errObj.Message != expected {
+				t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+			}
+		}
+	}
+}
+
+func TestArrowFunctions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"let double = x => x * 2; double(4)", 8},
+		{"let add = (a, b) => { a + b }; add(1, 2)", 3},
+		{"let f = () => 7; f()", 7},
+		{"let adder = a => b => a + b; adder(1)(2)", 3},
+		{"let f = ([a, b]) => a * b; f([3, 4])", 12},
+		{"let f = (x) => { if (x > 0) { return 1 }; -1 }; f(-5)", -1},
+		{"let f = (a, b) => a; f(1)", "wrong number of arguments to f. Got: 1. Want: 2"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			errObj, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("no error object returned. got=%T(%+v)", evaluated, evaluated)
+				continue
+			}
+			if errObj.Message != expected {
+				t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+			}
+		}
+	}
+}
+
+func TestFunctionHoisting(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"let r = double(4); fn double(x) { x * 2 }; r", 8},
+		{`
+fn isEven(n) { if (n == 0) { return true }; isOdd(n - 1) }
+fn isOdd(n) { if (n == 0) { return false }; isEven(n - 1) }
+isEven(10)`, true},
+		{"let f = fn() { let v = inner(); fn inner() { 5 }; v }; f()", 5},
+		{"let f = fn() { fn inner() { 5 }; 1 }; f(); inner()", "identifier not found: inner"},
+		{"if (true) { answer() }; fn answer() { 42 }; answer()", 42},
+		{"fn add(a, b) { a + b }; add(1)", "wrong number of arguments to add. Got: 1. Want: 2"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		case string:
+			errObj, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("no error object returned. got=%T(%+v)", evaluated, evaluated)
+				continue
+			}
+			if errObj.Message != expected {
+				t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+			}
+		}
+	}
+}
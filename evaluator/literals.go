@@ -1,7 +1,7 @@
 package evaluator
 
 import (
-	"strings"
+	"bytes"
 
 	"github.com/jumballaya/servo/ast"
 	"github.com/jumballaya/servo/object"
@@ -13,16 +13,22 @@ var (
 	NULL  = &object.Null{}
 )
 
+// Short Circuit is what an optional chain evaluates to while `?.` is skipping the rest of it.
+// Eval turns it into NULL, so it never escapes the chain. It can't be an object.Null, pointers
+// to empty structs aren't guaranteed to be different from NULL.
+type shortCircuit struct{ skipped bool }
+
+func (sc *shortCircuit) Type() object.ObjectType { return object.NULL_OBJ }
+func (sc *shortCircuit) Inspect() string         { return "null" }
+
+var shortCircuited = &shortCircuit{skipped: true}
+
 // Eval Identifier
 func evalIdentifier(node *ast.Identifier, env *object.Environment) object.Object {
 	if val, ok := env.Get(node.Value); ok {
 		return val
 	}
 
-	if env.Silent && node.Value == "log" {
-		return NULL
-	}
-
 	if builtin, ok := getBuiltin(node.Value, env); ok {
 		return builtin
 	}
@@ -49,11 +55,30 @@ func nativeBooleanToBooleanObject(val bool) *object.Boolean {
 	return FALSE
 }
 
-// Eval String Literal
+// Eval String Literal, escape sequences are already decoded by the lexer
 func evalStringLiteral(node *ast.StringLiteral, env *object.Environment) object.Object {
-	val := node.Value
-	val = strings.Replace(val, "\\n", "\n", -1)
-	val = strings.Replace(val, "\\t", "\t", -1)
-	val = strings.Replace(val, "\\r", "\r", -1)
-	return &object.String{Value: val}
+	return &object.String{Value: node.Value}
+}
+
+// Eval Bytes Literal copies the literal's bytes so the object can't modify the AST
+func evalBytesLiteral(node *ast.BytesLiteral, env *object.Environment) object.Object {
+	value := make([]byte, len(node.Value))
+	copy(value, node.Value)
+	return &object.Bytes{Value: value}
+}
+
+// Eval Template Literal joins the text chunks with the substitutions, converting each value
+// the same way it would be inspected
+func evalTemplateLiteral(node *ast.TemplateLiteral, env *object.Environment) object.Object {
+	var out bytes.Buffer
+
+	for _, part := range node.Parts {
+		val := Eval(part, env)
+		if isError(val) {
+			return val
+		}
+		out.WriteString(val.Inspect())
+	}
+
+	return &object.String{Value: out.String()}
 }
@@ -120,6 +120,10 @@ func TestStringLiteral(t *testing.T) {
 	}{
 		{`"Hello World!"`, "Hello World!"},
 		{`'Hello World!'`, "Hello World!"},
+		{`"it's"`, "it's"},
+		{`"say \"hi\""`, `say "hi"`},
+		{`"tab\tnew\nline"`, "tab\tnew\nline"},
+		{`"\u00e9t\u{E9}"`, "été"},
 	}
 
 	for _, tt := range tests {
@@ -134,3 +138,61 @@ func TestStringLiteral(t *testing.T) {
 		}
 	}
 }
+
+func TestTemplateLiteral(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"`plain`", "plain"},
+		{"let name = \"World\"; `Hello ${name}!`", "Hello World!"},
+		{"let items = [1, 2]; `${len(items)} items: ${items}`", "2 items: [1, 2]"},
+		{"`${1 + 2}${true}${null}`", "3trueNULL"},
+		{"let h = {\"a\": {\"b\": 5}}; `${h[\"a\"][\"b\"]}`", "5"},
+		{"`outer ${`inner ${1}`}`", "outer inner 1"},
+		{"`multi\nline`", "multi\nline"},
+	}
+
+	for _, tt := range tests {
+		testStringObject(t, testEval(tt.input), tt.expected)
+	}
+}
+
+func TestBytesLiteral(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`b'hi'`, "hi"},
+		{`b"\xde\xad"`, "\xde\xad"},
+		{`b'h' + b'i'`, "hi"},
+		{`b'hi'[1]`, "i"},
+		{`b'hi'[2]`, nil},
+		{`b'hi' == b'hi'`, true},
+		{`b'hi' != b'ho'`, true},
+		{`if (b'') { 1 } else { 2 }`, 2},
+		{`b'hi' + "hi"`, "type mismatch: BYTES + STRING"},
+		{`b'hi' - b'hi'`, "unknown operator: BYTES - BYTES"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case string:
+			if errObj, ok := evaluated.(*object.Error); ok {
+				if errObj.Message != expected {
+					t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+				}
+				continue
+			}
+			testBytesObject(t, evaluated, expected)
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case nil:
+			testNullObject(t, evaluated)
+		}
+	}
+}
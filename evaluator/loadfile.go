@@ -4,65 +4,67 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 
-	"github.com/jumballaya/servo/lexer"
 	"github.com/jumballaya/servo/object"
 	"github.com/jumballaya/servo/parser"
 )
 
 func FileExists(file string) bool {
 	stat, err := os.Stat(file)
-	return !os.IsNotExist(err) && !stat.IsDir()
+	return err == nil && !stat.IsDir()
 }
 
 func IsDir(file string) bool {
 	stat, err := os.Stat(file)
-	return !os.IsNotExist(err) && stat.IsDir()
+	return err == nil && stat.IsDir()
 }
 
+// Load And Eval File evaluates a file as a module, giving back the result of the program
 func LoadAndEvalFile(file string) object.Object {
-	requiredCode, err := LoadFile(file)
+	mod, err := loadFileModule(file)
 	if err != nil {
-		return newError(err.Error())
+		return err
 	}
-	env := object.NewEnvironment()
-	env.Silent = true
-	l := lexer.New(requiredCode)
-	p := parser.New(l)
-
-	program := p.ParseProgram()
-	if len(p.Errors()) != 0 {
-		return newError(strings.Join(p.Errors(), "\n"))
-	}
-
-	return Eval(program, env)
+	return modules.Evaluate(mod)
 }
 
+// Get Object From File evaluates a file as a module and gives back one of its bindings
 func GetObjectFromFile(file, objName string) object.Object {
-	requiredCode, err := LoadFile(file)
+	mod, err := loadFileModule(file)
 	if err != nil {
-		return newError(err.Error())
+		return err
 	}
-
-	env := object.NewEnvironment()
-	env.Silent = true
-	l := lexer.New(requiredCode)
-	p := parser.New(l)
-	program := p.ParseProgram()
-	Eval(program, env)
-
-	if len(p.Errors()) != 0 {
-		return newError(strings.Join(p.Errors(), "\n"))
+	if result := modules.Evaluate(mod); isError(result) {
+		return result
 	}
 
-	if val, ok := env.Get(objName); ok {
+	if val, ok := mod.Env.Get(objName); ok {
 		return val
 	}
 
 	return newError("identifier not found %s", objName)
 }
 
+func loadFileModule(file string) (*Module, *object.Error) {
+	path, err := filepath.Abs(file)
+	if err != nil {
+		return nil, newError("%s", err.Error())
+	}
+	return modules.Load(path)
+}
+
+// New Syntax Error turns the parse errors of a file into a single runtime error, files with
+// syntax errors are never evaluated
+func newSyntaxError(errors []*parser.ParseError) *object.Error {
+	msgs := make([]string, len(errors))
+	for i, err := range errors {
+		msgs[i] = err.Error()
+	}
+	return &object.Error{Message: strings.Join(msgs, "\n"), Kind: "SyntaxError"}
+}
+
 func LoadFile(file string) (string, error) {
 	data, err := ioutil.ReadFile(file)
 	if err != nil {
@@ -0,0 +1,32 @@
+package evaluator
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/jumballaya/servo/object"
+)
+
+func TestLoadFileWithSyntaxErrors(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "broken.svo")
+	if err := ioutil.WriteFile(file, []byte("let x = 1;\nlet y = ;\nlet = 2;"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, evaluated := range []object.Object{LoadAndEvalFile(file), GetObjectFromFile(file, "x")} {
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Fatalf("no error object returned. got=%T(%+v)", evaluated, evaluated)
+		}
+		if errObj.Kind != "SyntaxError" {
+			t.Errorf("wrong error kind. got=%q", errObj.Kind)
+		}
+
+		expected := file + ":2:9: no prefix parse function for ; found\nlet y = ;\n        ^\n" +
+			file + ":3:5: expected next token to be IDENT, got = instead\nlet = 2;\n    ^"
+		if errObj.Message != expected {
+			t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package evaluator
+
+import (
+	"sort"
+
+	"github.com/jumballaya/servo/ast"
+	"github.com/jumballaya/servo/object"
+)
+
+var (
+	BREAK    = &object.Break{}
+	CONTINUE = &object.Continue{}
+)
+
+// Eval For Statement runs the body once for every item of an array, hash, string or bytes.
+// Every iteration gets its own enclosed environment so closures made in the body capture
+// that iteration's values.
+//
+// With one name the loop binds array elements, hash keys or string characters. With two names
+// the first is bound to the index (or hash key) and the second to the element (or hash value).
+func evalForStatement(node *ast.ForStatement, env *object.Environment) object.Object {
+	iterable := Eval(node.Iterable, env)
+	if isError(iterable) {
+		return iterable
+	}
+
+	var keys, values []object.Object
+
+	switch it := iterable.(type) {
+	case *object.Array:
+		values = it.Elements
+		for i := range it.Elements {
+			keys = append(keys, &object.Integer{Value: int64(i)})
+		}
+	case *object.String:
+		for i, ch := range []rune(it.Value) {
+			keys = append(keys, &object.Integer{Value: int64(i)})
+			values = append(values, &object.String{Value: string(ch)})
+		}
+	case *object.Bytes:
+		for i, b := range it.Value {
+			keys = append(keys, &object.Integer{Value: int64(i)})
+			values = append(values, &object.Bytes{Value: []byte{b}})
+		}
+	case *object.Hash:
+		for _, pair := range sortedHashPairs(it) {
+			keys = append(keys, pair.Key)
+			values = append(values, pair.Value)
+		}
+		// A single name iterates over the keys of a hash
+		if node.Key == nil {
+			values = keys
+		}
+	default:
+		return newError("cannot iterate over %s", iterable.Type())
+	}
+
+	for i := range values {
+		iterEnv := object.NewEnclosedEnvironment(env)
+		if node.Key != nil {
+			iterEnv.Set(node.Key.Value, keys[i])
+		}
+		iterEnv.Set(node.Value.Value, values[i])
+
+		result := Eval(node.Body, iterEnv)
+		if result, stop := loopResult(result); stop {
+			return result
+		}
+	}
+
+	return NULL
+}
+
+// Eval While Statement runs the body in a new enclosed environment for as long as the condition
+// is truthy
+func evalWhileStatement(node *ast.WhileStatement, env *object.Environment) object.Object {
+	for {
+		condition := Eval(node.Condition, env)
+		if isError(condition) {
+			return condition
+		}
+		if !isTruthy(condition) {
+			return NULL
+		}
+
+		result := Eval(node.Body, object.NewEnclosedEnvironment(env))
+		if result, stop := loopResult(result); stop {
+			return result
+		}
+	}
+}
+
+// Loop Result checks the result of a loop body. It reports whether the loop should stop and
+// what it should give back: return values and errors keep unwinding, break ends the loop
+// with NULL and continue moves on to the next iteration.
+func loopResult(result object.Object) (object.Object, bool) {
+	if result == nil {
+		return nil, false
+	}
+
+	switch result.Type() {
+	case object.RETURN_VALUE_OBJ, object.ERROR_OBJ:
+		return result, true
+	case object.BREAK_OBJ:
+		return NULL, true
+	}
+
+	return nil, false
+}
+
+// Sorted Hash Pairs returns the pairs of a hash ordered by their keys so iterating over a hash
+// is deterministic
+func sortedHashPairs(hash *object.Hash) []object.HashPair {
+	pairs := make([]object.HashPair, 0, len(hash.Pairs))
+	for _, pair := range hash.Pairs {
+		pairs = append(pairs, pair)
+	}
+
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].Key.Inspect() < pairs[j].Key.Inspect()
+	})
+
+	return pairs
+}
@@ -0,0 +1,83 @@
+package evaluator
+
+import (
+	"testing"
+
+	"github.com/jumballaya/servo/object"
+)
+
+func TestForStatement(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"let sum = 0; for (x in [1, 2, 3]) { sum += x; }; sum", 6},
+		{"let sum = 0; for (i, x in [10, 20, 30]) { sum += i * x; }; sum", 80},
+		{"let out = ''; for (c in 'héllo') { out = c + out; }; out", "olléh"},
+		{"let out = ''; for (k in {'b': 2, 'a': 1}) { out += k; }; out", "ab"},
+		{"let out = ''; for (k, v in {'b': 2, 'a': 1}) { out += k + string(v); }; out", "a1b2"},
+		{"let n = 0; for (b in b'hi') { n += 1; }; n", 2},
+		{"for (x in []) { x }", nil},
+		{"let f = fn() { for (x in [1, 2, 3]) { if (x == 2) { return x; } } return 0; }; f()", 2},
+		{"let fns = []; for (x in [1, 2]) { fns = push(fns, fn() { x }); }; fns[0]() + fns[1]()", 3},
+		{"for (x in [1]) { let y = x; }; y", "identifier not found: y"},
+		{"for (x in [1]) { x + true; }", "type mismatch: INTEGER + BOOLEAN"},
+		{"for (x in 5) { x }", "cannot iterate over INTEGER"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			if errObj, ok := evaluated.(*object.Error); ok {
+				if errObj.Message != expected {
+					t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+				}
+				continue
+			}
+			testStringObject(t, evaluated, expected)
+		case nil:
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
+func TestWhileStatement(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"let i = 0; while (i < 5) { i += 1; }; i", 5},
+		{"let i = 0; while (true) { i += 1; if (i == 3) { break; } }; i", 3},
+		{"let i = 0; let sum = 0; while (i < 6) { i += 1; if (i % 2 == 0) { continue; } sum += i; }; sum", 9},
+		{"let sum = 0; for (x in [1, 2, 3, 4]) { if (x == 3) { break; } sum += x; }; sum", 3},
+		{"let sum = 0; for (x in [1, 2, 3, 4]) { if (x == 3) { continue; } sum += x; }; sum", 7},
+		{"let n = 0; for (x in [1, 2]) { for (y in [1, 2, 3]) { if (y == 2) { break; } n += 1; } }; n", 2},
+		{"let f = fn() { let i = 0; while (true) { i += 1; if (i > 2) { return i; } } }; f()", 3},
+		{"while (false) { 1 }", nil},
+		{"while (x) { 1 }", "identifier not found: x"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			errObj, ok := evaluated.(*object.Error)
+			if !ok {
+				t.Errorf("no error object returned. got=%T(%+v)", evaluated, evaluated)
+				continue
+			}
+			if errObj.Message != expected {
+				t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+			}
+		case nil:
+			testNullObject(t, evaluated)
+		}
+	}
+}
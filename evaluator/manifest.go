@@ -0,0 +1,184 @@
+package evaluator
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jumballaya/servo/object"
+)
+
+// ManifestFile is the name of the project manifest. Bare imports in a file are resolved through
+// the closest manifest in its directory or any of the directories above it.
+const ManifestFile = "servo.mod"
+
+// Manifest declares the root of a project and the local directories its dependencies are
+// vendored in, e.g.
+//
+//	module app
+//	root ./src
+//	require web ./vendor/web
+//
+// With it `import Router from 'web/router'` loads ./vendor/web/router.svo and
+// `import db from 'app/db'` or `import db from 'db'` load ./src/db.svo
+type Manifest struct {
+	Path     string // absolute path of the servo.mod file
+	Module   string // name that bare imports of files in the project start with
+	Root     string // absolute directory of the project's sources, defaults to the manifest's
+	Requires []*Requirement
+}
+
+// Requirement is a dependency of a project, imports starting with its name load files from Dir
+type Requirement struct {
+	Name string
+	Dir  string // absolute
+}
+
+// Parse Manifest reads the directives of a servo.mod file, one per line. Paths are relative to
+// the directory the file is in and `#` starts a comment.
+func ParseManifest(path, src string) (*Manifest, error) {
+	dir := filepath.Dir(path)
+	m := &Manifest{Path: path, Root: dir}
+
+	for i, line := range strings.Split(src, "\n") {
+		if c := strings.Index(line, "#"); c >= 0 {
+			line = line[:c]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		directive, args := fields[0], fields[1:]
+		want := map[string]int{"module": 1, "root": 1, "require": 2}[directive]
+		if want == 0 {
+			return nil, fmt.Errorf("%s:%d: unknown directive %q", path, i+1, directive)
+		}
+		if len(args) != want {
+			return nil, fmt.Errorf("%s:%d: %s takes %d arguments, got %d", path, i+1, directive, want, len(args))
+		}
+
+		switch directive {
+		case "module":
+			m.Module = args[0]
+		case "root":
+			m.Root = joinDir(dir, args[0])
+		case "require":
+			m.Requires = append(m.Requires, &Requirement{Name: args[0], Dir: joinDir(dir, args[1])})
+		}
+	}
+
+	return m, nil
+}
+
+// Directories gives back where a bare import path can be found, in the order they are searched:
+// the dependency it names, the project itself, then the project root
+func (m *Manifest) Directories(path string) []string {
+	dirs := []string{}
+	for _, req := range m.Requires {
+		if rest, ok := trimPathPrefix(path, req.Name); ok {
+			dirs = append(dirs, filepath.Join(req.Dir, rest))
+		}
+	}
+	if m.Module != "" {
+		if rest, ok := trimPathPrefix(path, m.Module); ok {
+			dirs = append(dirs, filepath.Join(m.Root, rest))
+		}
+	}
+	return append(dirs, filepath.Join(m.Root, path))
+}
+
+// Find Manifest looks for the servo.mod closest to the directory dir. Manifests are cached, so
+// each one is only read once.
+func (ml *ModuleLoader) findManifest(dir string) (*Manifest, *object.Error) {
+	for {
+		path := filepath.Join(dir, ManifestFile)
+		if m, ok := ml.manifests[path]; ok {
+			return m, nil
+		}
+
+		if FileExists(path) {
+			src, err := ioutil.ReadFile(path)
+			if err != nil {
+				return nil, &object.Error{Message: "cannot load manifest: " + err.Error(), Kind: "ImportError"}
+			}
+			m, err := ParseManifest(path, string(src))
+			if err != nil {
+				return nil, &object.Error{Message: err.Error(), Kind: "ImportError"}
+			}
+			ml.manifests[path] = m
+			return m, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// Resolve Bare finds the file a bare import like `web/router` in the file `from` points at. It
+// searches the directories of the closest manifest and then every directory in SERVO_PATH. The
+// `.svo` extension is added when the path doesn't have one.
+func (ml *ModuleLoader) resolveBare(path, from string) (string, *object.Error) {
+	dir := filepath.Dir(from)
+	if from == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return "", &object.Error{Message: err.Error(), Kind: "ImportError"}
+		}
+		dir = wd
+	}
+
+	candidates := []string{}
+	m, err := ml.findManifest(dir)
+	if err != nil {
+		return "", err
+	}
+	if m != nil {
+		candidates = append(candidates, m.Directories(path)...)
+	}
+	for _, dir := range filepath.SplitList(os.Getenv("SERVO_PATH")) {
+		if abs, err := filepath.Abs(dir); dir != "" && err == nil {
+			candidates = append(candidates, filepath.Join(abs, path))
+		}
+	}
+
+	for _, candidate := range candidates {
+		if filepath.Ext(candidate) == "" {
+			candidate += ".svo"
+		}
+		if FileExists(candidate) {
+			return candidate, nil
+		}
+	}
+
+	msg := fmt.Sprintf("cannot find module '%s'", path)
+	if m == nil && os.Getenv("SERVO_PATH") == "" {
+		msg += ", there is no " + ManifestFile + " and SERVO_PATH is not set"
+	}
+	return "", &object.Error{Message: msg, Kind: "ImportError"}
+}
+
+// Join Dir makes dir absolute, relative directories start from base
+func joinDir(base, dir string) string {
+	if filepath.IsAbs(dir) {
+		return filepath.Clean(dir)
+	}
+	if abs, err := filepath.Abs(filepath.Join(base, dir)); err == nil {
+		return abs
+	}
+	return filepath.Join(base, dir)
+}
+
+// Trim Path Prefix removes the leading path segments prefix from path, e.g. `web/router` with
+// the prefix `web` gives back `router`
+func trimPathPrefix(path, prefix string) (string, bool) {
+	if !strings.HasPrefix(path, prefix+"/") {
+		return "", false
+	}
+	return strings.TrimPrefix(path, prefix+"/"), true
+}
@@ -0,0 +1,137 @@
+package evaluator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jumballaya/servo/object"
+)
+
+func TestParseManifest(t *testing.T) {
+	input := `
+# The project
+module app
+root ./src   # sources live here
+
+require web ./vendor/web
+require github.com/x/db /opt/db
+`
+	m, err := ParseManifest("/home/app/servo.mod", input)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if m.Module != "app" {
+		t.Errorf("m.Module is not %q. got=%q", "app", m.Module)
+	}
+	if m.Root != "/home/app/src" {
+		t.Errorf("m.Root is not %q. got=%q", "/home/app/src", m.Root)
+	}
+	if len(m.Requires) != 2 {
+		t.Fatalf("m.Requires does not contain 2 requirements. got=%d", len(m.Requires))
+	}
+
+	tests := []struct {
+		path     string
+		expected []string
+	}{
+		{"web/router", []string{"/home/app/vendor/web/router", "/home/app/src/web/router"}},
+		{"github.com/x/db/conn", []string{"/opt/db/conn", "/home/app/src/github.com/x/db/conn"}},
+		{"app/models/user", []string{"/home/app/src/models/user", "/home/app/src/app/models/user"}},
+		{"webby", []string{"/home/app/src/webby"}},
+	}
+
+	for _, tt := range tests {
+		dirs := m.Directories(tt.path)
+		if strings.Join(dirs, ",") != strings.Join(tt.expected, ",") {
+			t.Errorf("wrong directories for %q. expected=%v, got=%v", tt.path, tt.expected, dirs)
+		}
+	}
+}
+
+func TestInvalidManifest(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"module app\nrequires web ./web", `/app/servo.mod:2: unknown directive "requires"`},
+		{"module", "/app/servo.mod:1: module takes 1 arguments, got 0"},
+		{"require web", "/app/servo.mod:1: require takes 2 arguments, got 1"},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseManifest("/app/servo.mod", tt.input)
+		if err == nil {
+			t.Errorf("expected an error for %q", tt.input)
+			continue
+		}
+		if err.Error() != tt.expected {
+			t.Errorf("wrong error. expected=%q, got=%q", tt.expected, err.Error())
+		}
+	}
+}
+
+func TestBareImports(t *testing.T) {
+	dir := writeModules(t, map[string]string{
+		"project/servo.mod":             "module app\nroot ./src\nrequire web ./vendor/web\n",
+		"project/src/db.svo":            "export let db = 'db';",
+		"project/src/helpers.svo":       "export let helper = 'helper';",
+		"project/vendor/web/router.svo": "import {route} from './route.svo'; export let Router = route('router');",
+		"project/vendor/web/route.svo":  "export let route = fn(name) { name };",
+		"shared/lib/util.svo":           "export let util = 'util';",
+		"project/src/main.svo": `
+import {Router} from 'web/router';
+import {db} from 'app/db';
+import {helper} from 'helpers.svo';
+import {util} from 'lib/util';
+[Router, db, helper, util]`,
+		"project/src/missing.svo":   "let x = 1;\nimport x from 'web/nope';",
+		"project/src/bad/servo.mod": "module bad\nrequires web ./web",
+		"project/src/bad/main.svo":  "import x from 'web/router';",
+		"alone/main.svo":            "import x from 'web/router';",
+	})
+	t.Setenv("SERVO_PATH", filepath.Join(dir, "nope")+string(filepath.ListSeparator)+filepath.Join(dir, "shared"))
+
+	evaluated := LoadAndEvalFile(filepath.Join(dir, "project/src/main.svo"))
+	array, ok := evaluated.(*object.Array)
+	if !ok {
+		t.Fatalf("object is not Array. got=%T (%+v)", evaluated, evaluated)
+	}
+	for i, expected := range []string{"router", "db", "helper", "util"} {
+		testStringObject(t, array.Elements[i], expected)
+	}
+
+	tests := []struct {
+		file     string
+		servo    bool // whether SERVO_PATH is set
+		expected string
+	}{
+		{"project/src/missing.svo", true, "cannot find module 'web/nope'"},
+		{"project/src/bad/main.svo", true, `$DIR/project/src/bad/servo.mod:2: unknown directive "requires"`},
+		{"alone/main.svo", true, "cannot find module 'web/router'"},
+		{"alone/main.svo", false, "cannot find module 'web/router', there is no servo.mod and SERVO_PATH is not set"},
+	}
+
+	for _, tt := range tests {
+		if !tt.servo {
+			t.Setenv("SERVO_PATH", "")
+		}
+
+		evaluated := LoadAndEvalFile(filepath.Join(dir, tt.file))
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned. got=%T(%+v)", evaluated, evaluated)
+			continue
+		}
+		if errObj.Kind != "ImportError" {
+			t.Errorf("wrong error kind. expected=%q, got=%q", "ImportError", errObj.Kind)
+		}
+		if expected := strings.Replace(tt.expected, "$DIR", dir, -1); errObj.Message != expected {
+			t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+		}
+		if errObj.Pos.Filename != filepath.Join(dir, tt.file) {
+			t.Errorf("error is not at the import. got=%s", errObj.Pos)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package evaluator
+
+import (
+	"github.com/jumballaya/servo/ast"
+	"github.com/jumballaya/servo/object"
+)
+
+// Eval Match Expression tries each arm in order and evaluates the body of the first one whose
+// pattern matches and whose guard is truthy. Names bound by the pattern are only visible to that
+// arm's guard and body. It is an error when no arm matches.
+func evalMatchExpression(me *ast.MatchExpression, env *object.Environment) object.Object {
+	subject := Eval(me.Subject, env)
+	if isError(subject) {
+		return subject
+	}
+
+	for _, arm := range me.Arms {
+		armEnv := object.NewEnclosedEnvironment(env)
+
+		matched, err := matchPattern(arm.Pattern, subject, armEnv)
+		if err != nil {
+			return err
+		}
+		if !matched {
+			continue
+		}
+
+		if arm.Guard != nil {
+			guard := Eval(arm.Guard, armEnv)
+			if isError(guard) {
+				return guard
+			}
+			if !isTruthy(guard) {
+				continue
+			}
+		}
+
+		return Eval(arm.Body, armEnv)
+	}
+
+	return newError("no match arm matched %s", subject.Inspect())
+}
+
+// Match Pattern checks a value against a match pattern, binding names into env as it goes. The
+// error is only set when evaluating part of the pattern failed, a value that doesn't fit is just
+// not a match.
+func matchPattern(pattern ast.Pattern, val object.Object, env *object.Environment) (bool, object.Object) {
+	switch pattern := pattern.(type) {
+	case *ast.WildcardPattern:
+		return true, nil
+
+	case *ast.Identifier:
+		env.Set(pattern.Value, val)
+		return true, nil
+
+	case *ast.LiteralPattern:
+		literal := Eval(pattern.Value, env)
+		if isError(literal) {
+			return false, literal
+		}
+		return isTrue(evalInfixExpression("==", val, literal)), nil
+
+	case *ast.RangePattern:
+		return matchRange(pattern, val, env)
+
+	case *ast.ClassPattern:
+		obj := Eval(pattern.Class, env)
+		if isError(obj) {
+			return false, obj
+		}
+		class, ok := obj.(*object.Class)
+		if !ok {
+			return false, newError("%s is not a class", pattern.Class.Value)
+		}
+		instance, ok := val.(*object.Instance)
+		if !ok || !object.InstanceOf(class.Name, instance) {
+			return false, nil
+		}
+		if pattern.Name != nil {
+			env.Set(pattern.Name.Value, val)
+		}
+		return true, nil
+
+	case *ast.ArrayPattern:
+		array, ok := val.(*object.Array)
+		if !ok {
+			return false, nil
+		}
+		if len(array.Elements) < len(pattern.Elements) {
+			return false, nil
+		}
+		if pattern.Rest == nil && len(array.Elements) != len(pattern.Elements) {
+			return false, nil
+		}
+
+		for i, el := range pattern.Elements {
+			matched, err := matchPattern(el, array.Elements[i], env)
+			if err != nil || !matched {
+				return false, err
+			}
+		}
+
+		if pattern.Rest != nil {
+			rest := append([]object.Object{}, array.Elements[len(pattern.Elements):]...)
+			env.Set(pattern.Rest.Value, &object.Array{Elements: rest})
+		}
+		return true, nil
+
+	case *ast.HashPattern:
+		hash, ok := val.(*object.Hash)
+		if !ok {
+			return false, nil
+		}
+
+		used := map[object.HashKey]bool{}
+		for _, entry := range pattern.Entries {
+			key := (&object.String{Value: entry.Key.Value}).HashKey()
+			pair, ok := hash.Pairs[key]
+			if !ok {
+				return false, nil
+			}
+			used[key] = true
+
+			matched, err := matchPattern(entry.Value, pair.Value, env)
+			if err != nil || !matched {
+				return false, err
+			}
+		}
+
+		if pattern.Rest != nil {
+			rest := &object.Hash{Pairs: map[object.HashKey]object.HashPair{}}
+			for key, pair := range hash.Pairs {
+				if !used[key] {
+					rest.Pairs[key] = pair
+				}
+			}
+			env.Set(pattern.Rest.Value, rest)
+		}
+		return true, nil
+	}
+
+	return false, newError("unknown pattern: %s", pattern.String())
+}
+
+// Match Range checks that a number or string is between the bounds of a range, both included.
+// Values of other types never match.
+func matchRange(pattern *ast.RangePattern, val object.Object, env *object.Environment) (bool, object.Object) {
+	low := Eval(pattern.Low, env)
+	if isError(low) {
+		return false, low
+	}
+	high := Eval(pattern.High, env)
+	if isError(high) {
+		return false, high
+	}
+
+	switch {
+	case isNumber(val.Type()) && isNumber(low.Type()) && isNumber(high.Type()):
+		return isTrue(evalInfixExpression(">=", val, low)) && isTrue(evalInfixExpression("<=", val, high)), nil
+	case val.Type() == object.STRING_OBJ && low.Type() == object.STRING_OBJ && high.Type() == object.STRING_OBJ:
+		str := val.(*object.String).Value
+		return str >= low.(*object.String).Value && str <= high.(*object.String).Value, nil
+	}
+
+	return false, nil
+}
+
+// Is True checks for a boolean true result, comparisons don't always return the TRUE singleton
+func isTrue(obj object.Object) bool {
+	b, ok := obj.(*object.Boolean)
+	return ok && b.Value
+}
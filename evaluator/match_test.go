@@ -0,0 +1,71 @@
+package evaluator
+
+import (
+	"testing"
+
+	"github.com/jumballaya/servo/object"
+)
+
+func TestMatchExpression(t *testing.T) {
+	classes := `
+class Vehicle {};
+class Car::Vehicle { let wheels = 4; };
+class Bike::Vehicle { let wheels = 2; };
+`
+
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"match (1) { 1 => 'one', _ => 'other' }", "one"},
+		{"match (5) { 1 => 'one', _ => 'other' }", "other"},
+		{"match ('GET') { 'POST' => 1, 'GET' => 2 }", 2},
+		{"match (-3) { -3 => true, _ => false }", true},
+		{"match (null) { null => 1, _ => 2 }", 1},
+		{"match (2.0) { 2 => 'two' }", "two"},
+		{"match (7) { 0..5 => 'low', 6..10 => 'high' }", "high"},
+		{"match (5) { 0..5 => 'low', 6..10 => 'high' }", "low"},
+		{"match ('m') { 'a'..'l' => 1, 'm'..'z' => 2 }", 2},
+		{"match ('5') { 0..9 => 1, _ => 2 }", 2},
+		{"match (4) { n if n % 2 == 0 => n * 10, n => n }", 40},
+		{"match (3) { n if n % 2 == 0 => n * 10, n => n }", 3},
+		{"match ([1, 2]) { [a] => a, [a, b] => a + b }", 3},
+		{"match ([1, 2, 3]) { [0, ...rest] => 0, [1, ...rest] => len(rest) }", 2},
+		{"match ([1, 2, 3]) { [a, b] => 1, _ => 2 }", 2},
+		{"match ({'a': 1}) { [a] => a, {a} => a + 1 }", 2},
+		{`match ({"method": "GET", "path": "/"}) { {method: "POST"} => 1, {method: "GET", path} => path }`, "/"},
+		{`match ({"kind": "user", "name": "ann", "age": 3}) { {kind: "user", ...rest} => rest.kind ?? rest.name }`, "ann"},
+		{"match ({'a': {'b': [1, 5]}}) { {a: {b: [1, x]}} => x }", 5},
+		{"match (1) { x => x }; x", "identifier not found: x"},
+		{"let f = fn(x) { match (x) { 0 => { return 'early' }, _ => 1 }; 'late' }; f(0)", "early"},
+		{"match (1 + 1) { 2 => { let y = 3; y * 2 } }", 6},
+		{classes + "match (new Car()) { instanceof Bike => 1, v instanceof Vehicle => v.wheels }", 4},
+		{classes + "match (new Bike()) { c instanceof Car => c.wheels, b instanceof Bike => b.wheels }", 2},
+		{classes + "match (5) { instanceof Car => 1, _ => 2 }", 2},
+		{"match (5) { instanceof Nope => 1 }", "identifier not found: Nope"},
+		{"let Nope = 1; match (5) { instanceof Nope => 1 }", "Nope is not a class"},
+		{"match (3) { 1 => 'one', 2 => 'two' }", "no match arm matched 3"},
+		{"match ([1]) { [] => 0 }", "no match arm matched [1]"},
+		{"match (1) { n if n > 1 => n }", "no match arm matched 1"},
+		{"match (1) { n if m => n }", "identifier not found: m"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		case string:
+			if errObj, ok := evaluated.(*object.Error); ok {
+				if errObj.Message != expected {
+					t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+				}
+				continue
+			}
+			testStringObject(t, evaluated, expected)
+		}
+	}
+}
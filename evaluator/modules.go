@@ -0,0 +1,377 @@
+package evaluator
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/jumballaya/servo/ast"
+	"github.com/jumballaya/servo/lexer"
+	"github.com/jumballaya/servo/object"
+	"github.com/jumballaya/servo/parser"
+)
+
+// Module is a source file loaded by the module loader. It is parsed and evaluated once, and
+// every file that imports it shares its environment.
+type Module struct {
+	Path    string // absolute path of the file
+	Program *ast.Program
+	Imports []*Module // modules imported at the top level of the file, in order
+	Exports []string  // names declared with `export`, in order, the rest of the module is private
+	Env     *object.Environment
+
+	state     moduleState
+	result    object.Object // what evaluating the program returned
+	namespace *object.Hash  // the exports once the module is evaluated
+	imported  bool          // loaded by an import, it is silent until it is evaluated
+	done      chan struct{} // closed once the module is evaluated
+	owner     int64         // goroutine evaluating the module
+}
+
+type moduleState int
+
+const (
+	moduleLoaded moduleState = iota
+	moduleEvaluating
+	moduleEvaluated
+)
+
+// Module Loader keeps the dependency graph of every module in the program, keyed by absolute
+// path. Loading a module parses it and everything it imports before anything is evaluated,
+// so syntax errors and import cycles are found up front. It is safe for concurrent use, e.g.
+// from server handlers that import inside of functions.
+type ModuleLoader struct {
+	mu        sync.Mutex // guards everything below and the state of every module
+	modules   map[string]*Module
+	manifests map[string]*Manifest // servo.mod files by path
+	loading   []*Module            // modules whose imports are being loaded, innermost last
+	waiting   map[int64]*Module    // modules goroutines are waiting on another goroutine to evaluate
+}
+
+// NewModuleLoader creates an empty module loader
+func NewModuleLoader() *ModuleLoader {
+	return &ModuleLoader{
+		modules:   map[string]*Module{},
+		manifests: map[string]*Manifest{},
+		waiting:   map[int64]*Module{},
+	}
+}
+
+// The loader shared by every import in the program
+var modules = NewModuleLoader()
+
+// Run Module evaluates an already parsed program as the module at path, usually the script
+// being run. Its imports are loaded first, so the program doesn't run if any of them fail.
+func RunModule(path string, program *ast.Program, env *object.Environment) object.Object {
+	mod, err := modules.Register(path, program, env)
+	if err != nil {
+		return err
+	}
+	return modules.Evaluate(mod)
+}
+
+// Load reads and parses the module at path along with everything it imports. Files that were
+// already loaded come from the cache.
+func (ml *ModuleLoader) Load(path string) (*Module, *object.Error) {
+	ml.mu.Lock()
+	defer ml.mu.Unlock()
+	return ml.load(path)
+}
+
+func (ml *ModuleLoader) load(path string) (*Module, *object.Error) {
+	if mod, ok := ml.modules[path]; ok {
+		if err := ml.cycleError(mod); err != nil {
+			return nil, err
+		}
+		return mod, nil
+	}
+
+	src, readErr := ioutil.ReadFile(path)
+	if readErr != nil {
+		return nil, &object.Error{Message: "cannot load module: " + readErr.Error(), Kind: "ImportError"}
+	}
+
+	l := lexer.NewWithFilename(string(src), path)
+	p := parser.New(l)
+	program := p.ParseProgram()
+	if len(p.Errors()) != 0 {
+		return nil, newSyntaxError(p.Errors())
+	}
+
+	// Importing a module doesn't print anything, even from the functions it calls while it is
+	// evaluated. They log as usual once it is.
+	env := object.NewEnvironment()
+	env.Silent = true
+	mod, err := ml.register(path, program, env)
+	if err != nil {
+		return nil, err
+	}
+	mod.imported = true
+	return mod, nil
+}
+
+// Register adds a program that was already parsed as the module at path, then loads the
+// modules it imports at the top level
+func (ml *ModuleLoader) Register(path string, program *ast.Program, env *object.Environment) (*Module, *object.Error) {
+	ml.mu.Lock()
+	defer ml.mu.Unlock()
+	return ml.register(path, program, env)
+}
+
+func (ml *ModuleLoader) register(path string, program *ast.Program, env *object.Environment) (*Module, *object.Error) {
+	mod := &Module{Path: path, Program: program, Env: env}
+	env.File = path
+
+	for _, stmt := range program.Statements {
+		if export, ok := stmt.(*ast.ExportStatement); ok {
+			mod.Exports = append(mod.Exports, export.Names()...)
+		}
+	}
+	ml.modules[path] = mod
+
+	ml.loading = append(ml.loading, mod)
+	defer func() { ml.loading = ml.loading[:len(ml.loading)-1] }()
+
+	for _, stmt := range program.Statements {
+		exp, ok := stmt.(*ast.ExpressionStatement)
+		if !ok {
+			continue
+		}
+		imp, ok := exp.Expression.(*ast.ImportExpression)
+		if !ok || isLibraryImport(imp.Path.Value, env) {
+			continue
+		}
+
+		dep, err := ml.importModule(imp, path)
+		if err == nil {
+			err = dep.checkImports(imp)
+		}
+		if err != nil {
+			// Modules that failed to load are dropped so nothing uses them half linked
+			delete(ml.modules, path)
+			return nil, err
+		}
+		mod.Imports = append(mod.Imports, dep)
+	}
+
+	return mod, nil
+}
+
+// Load Import loads the module an import expression in the file `from` refers to. Errors are
+// tagged with the position of the import unless they happened inside of the imported file.
+func (ml *ModuleLoader) loadImport(imp *ast.ImportExpression, from string) (*Module, *object.Error) {
+	ml.mu.Lock()
+	defer ml.mu.Unlock()
+	return ml.importModule(imp, from)
+}
+
+func (ml *ModuleLoader) importModule(imp *ast.ImportExpression, from string) (*Module, *object.Error) {
+	path, err := ml.resolve(imp.Path.Value, from)
+	if err == nil {
+		var mod *Module
+		if mod, err = ml.load(path); err == nil {
+			return mod, nil
+		}
+	}
+
+	if !err.Pos.IsValid() {
+		err.Pos = imp.Pos()
+	}
+	return nil, err
+}
+
+// Resolve finds the absolute path of the file an import in the file `from` points at. Relative
+// and absolute paths are files, anything else is a bare import found through the manifest or
+// SERVO_PATH.
+func (ml *ModuleLoader) resolve(path, from string) (string, *object.Error) {
+	if !isFileImport(path) {
+		return ml.resolveBare(path, from)
+	}
+
+	resolved, err := resolvePath(path, from)
+	if err != nil {
+		return "", &object.Error{Message: err.Error(), Kind: "ImportError"}
+	}
+	return resolved, nil
+}
+
+// Evaluate runs a module the first time it is needed, after that it gives back the result of
+// that first run. Its environment is shared with everything that imports it. A module another
+// goroutine is evaluating is waited on, so every importer sees it fully evaluated.
+func (ml *ModuleLoader) Evaluate(mod *Module) object.Object {
+	g := goroutineID()
+
+	ml.mu.Lock()
+	switch mod.state {
+	case moduleEvaluated:
+		ml.mu.Unlock()
+		return mod.result
+	case moduleEvaluating:
+		if ml.waitsOn(g, mod) {
+			ml.mu.Unlock()
+			// Only imports that aren't at the top level of a file can get here, the others
+			// are checked for cycles when they are loaded
+			msg := "import cycle: " + filepath.Base(mod.Path) + " is imported while it is still being evaluated"
+			return &object.Error{Message: msg, Kind: "ImportError"}
+		}
+		ml.waiting[g] = mod
+		ml.mu.Unlock()
+
+		<-mod.done
+
+		ml.mu.Lock()
+		delete(ml.waiting, g)
+		ml.mu.Unlock()
+		return mod.result
+	}
+	mod.state = moduleEvaluating
+	mod.owner = g
+	mod.done = make(chan struct{})
+	ml.mu.Unlock()
+
+	// The lock isn't held while the program runs, it may import other modules
+	result := Eval(mod.Program, mod.Env)
+
+	ml.mu.Lock()
+	mod.result = result
+	mod.namespace = mod.exportsHash()
+	mod.state = moduleEvaluated
+	if mod.imported {
+		mod.Env.Silent = false
+	}
+	ml.mu.Unlock()
+	close(mod.done)
+
+	return result
+}
+
+// Waits On checks if the goroutine g is already evaluating mod, either itself or through the
+// goroutines it is waiting on. Waiting for mod to finish would then never return.
+func (ml *ModuleLoader) waitsOn(g int64, mod *Module) bool {
+	for mod != nil && mod.state == moduleEvaluating {
+		if mod.owner == g {
+			return true
+		}
+		mod = ml.waiting[mod.owner]
+	}
+	return false
+}
+
+// Goroutine ID gives back the id of the current goroutine from the header of its stack trace,
+// e.g. `goroutine 18 [running]:`
+func goroutineID() int64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	id, _ := strconv.ParseInt(string(buf[:bytes.IndexByte(buf, ' ')]), 10, 64)
+	return id
+}
+
+// Check Imports makes sure the module exports every name an import of it asks for
+func (m *Module) checkImports(imp *ast.ImportExpression) *object.Error {
+	for _, spec := range imp.Imports() {
+		if !m.exports(spec.Name.Value) {
+			msg := fmt.Sprintf("%s is not exported by %s", spec.Name.Value, imp.Path.Value)
+			return &object.Error{Message: msg, Kind: "ImportError", Pos: spec.Name.Pos()}
+		}
+	}
+	return nil
+}
+
+func (m *Module) exports(name string) bool {
+	for _, export := range m.Exports {
+		if export == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Namespace gives back the hash of everything the module exports, which is what importing the
+// whole module binds. It is built once, when the module is evaluated, so every import of the
+// module shares it and it holds the values the exports had then. Rebinding an export later,
+// e.g. from one of the module's functions, doesn't change it.
+func (m *Module) Namespace() *object.Hash {
+	return m.namespace
+}
+
+// Exports Hash reads the current value of every export into a new hash
+func (m *Module) exportsHash() *object.Hash {
+	hash := &object.Hash{Pairs: map[object.HashKey]object.HashPair{}}
+	for _, name := range m.Exports {
+		val, ok := m.Env.Get(name)
+		if !ok {
+			val = NULL
+		}
+		key := &object.String{Value: name}
+		hash.Pairs[key.HashKey()] = object.HashPair{Key: key, Value: val}
+	}
+	return hash
+}
+
+// Cycle Error reports an import cycle when mod is still loading its own imports, e.g.
+// `import cycle: a.svo -> b.svo -> a.svo`
+func (ml *ModuleLoader) cycleError(mod *Module) *object.Error {
+	for i, loading := range ml.loading {
+		if loading != mod {
+			continue
+		}
+
+		root := filepath.Dir(mod.Path)
+		chain := []string{}
+		for _, m := range ml.loading[i:] {
+			chain = append(chain, ml.displayPath(root, m.Path))
+		}
+		chain = append(chain, ml.displayPath(root, mod.Path))
+
+		return &object.Error{Message: "import cycle: " + strings.Join(chain, " -> "), Kind: "ImportError"}
+	}
+
+	return nil
+}
+
+// Display Path shortens path to be relative to the directory root when it is inside of it
+func (ml *ModuleLoader) displayPath(root, path string) string {
+	if rel, err := filepath.Rel(root, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return path
+}
+
+// Resolve Path finds the absolute path of a file used by the file `from`. Relative paths start
+// from the directory `from` is in, or from the working directory when there is no file, e.g. in
+// the REPL.
+func resolvePath(path, from string) (string, error) {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path), nil
+	}
+	if from == "" {
+		return filepath.Abs(path)
+	}
+	return filepath.Join(filepath.Dir(from), path), nil
+}
+
+// Is Library Import checks if an import is of a library hash bound in the environment, like the
+// ones in the standard library environment, rather than a module
+func isLibraryImport(path string, env *object.Environment) bool {
+	if isFileImport(path) {
+		return false
+	}
+	lib, ok := env.Get(path)
+	if !ok {
+		return false
+	}
+	_, ok = lib.(*object.Hash)
+	return ok
+}
+
+// Is File Import checks if an import path points at a file rather than a standard library
+// module
+func isFileImport(path string) bool {
+	return strings.HasPrefix(path, "./") || strings.HasPrefix(path, "../") || strings.HasPrefix(path, "/")
+}
@@ -0,0 +1,351 @@
+package evaluator
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jumballaya/servo/lexer"
+	"github.com/jumballaya/servo/object"
+	"github.com/jumballaya/servo/parser"
+)
+
+// Write Modules creates the files in a temporary directory. `$DIR` in their source is replaced
+// with the directory so they can import each other.
+func writeModules(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, src := range files {
+		src = strings.Replace(src, "$DIR", dir, -1)
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestModuleSharedInstance(t *testing.T) {
+	dir := writeModules(t, map[string]string{
+		"counter.svo": "let state = {'count': 0}; export let bump = fn() { state.count += 1; state.count };",
+		"a.svo":       "import bump from '$DIR/counter.svo'; export let a = bump();",
+		"b.svo":       "import bump from '$DIR/counter.svo'; export let b = bump();",
+	})
+
+	input := strings.Replace(`
+import bump from '$DIR/counter.svo';
+import a from '$DIR/a.svo';
+import b from '$DIR/b.svo';
+import a from '$DIR/a.svo';
+[a, b, bump()]`, "$DIR", dir, -1)
+
+	evaluated := testEval(input)
+	array, ok := evaluated.(*object.Array)
+	if !ok {
+		t.Fatalf("object is not Array. got=%T (%+v)", evaluated, evaluated)
+	}
+	for i, expected := range []int64{1, 2, 3} {
+		testIntegerObject(t, array.Elements[i], expected)
+	}
+}
+
+func TestModuleLoaderGraph(t *testing.T) {
+	dir := writeModules(t, map[string]string{
+		"main.svo": "import a from '$DIR/a.svo'; import b from '$DIR/b.svo';",
+		"a.svo":    "import b from '$DIR/b.svo'; export let a = b + 1;",
+		"b.svo":    "export let b = 1;",
+	})
+
+	// Imports are evaluated by the shared loader, so the graph has to come from it too
+	loader := modules
+	main, err := loader.Load(filepath.Join(dir, "main.svo"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Inspect())
+	}
+
+	if len(main.Imports) != 2 {
+		t.Fatalf("main has wrong number of imports. got=%d", len(main.Imports))
+	}
+	a, b := main.Imports[0], main.Imports[1]
+	if len(a.Imports) != 1 || a.Imports[0] != b {
+		t.Errorf("a.svo and main.svo don't share b.svo")
+	}
+
+	again, _ := loader.Load(filepath.Join(dir, "b.svo"))
+	if again != b {
+		t.Errorf("b.svo was loaded twice")
+	}
+
+	if result := loader.Evaluate(main); isError(result) {
+		t.Fatalf("unexpected error: %s", result.Inspect())
+	}
+	if b.state != moduleEvaluated || a.state != moduleEvaluated {
+		t.Errorf("imports were not evaluated")
+	}
+}
+
+func TestModuleErrors(t *testing.T) {
+	dir := writeModules(t, map[string]string{
+		"a.svo":      "let x = 1;\nimport b from '$DIR/b.svo';",
+		"b.svo":      "import c from '$DIR/c.svo';",
+		"c.svo":      "\nimport a from '$DIR/a.svo';",
+		"lib.svo":    "let x = 1; export let y = 1;",
+		"broken.svo": "let y = ;",
+		"throws.svo": "export let y = 1 + true;",
+	})
+
+	tests := []struct {
+		input    string
+		kind     string
+		expected string
+	}{
+		{"import a from '$DIR/a.svo'", "ImportError", "import cycle: a.svo -> b.svo -> c.svo -> a.svo"},
+		{"import x from '$DIR/lib.svo'", "ImportError", "x is not exported by $DIR/lib.svo"},
+		{"import x from '$DIR/nope.svo'", "ImportError", "cannot load module: open $DIR/nope.svo: no such file or directory"},
+		{"import y from '$DIR/broken.svo'", "SyntaxError", "$DIR/broken.svo:1:9: no prefix parse function for ; found\nlet y = ;\n        ^"},
+		{"import y from '$DIR/throws.svo'", "", "type mismatch: INTEGER + BOOLEAN"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(strings.Replace(tt.input, "$DIR", dir, -1))
+
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned. got=%T(%+v)", evaluated, evaluated)
+			continue
+		}
+		if errObj.Kind != tt.kind {
+			t.Errorf("wrong error kind. expected=%q, got=%q", tt.kind, errObj.Kind)
+		}
+		expected := strings.Replace(tt.expected, "$DIR", dir, -1)
+		if errObj.Message != expected {
+			t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+		}
+	}
+
+	// Cycles are reported at the import that closes them
+	_, err := NewModuleLoader().Load(filepath.Join(dir, "a.svo"))
+	if err == nil {
+		t.Fatalf("expected an import cycle error")
+	}
+	if err.Pos.Filename != filepath.Join(dir, "c.svo") || err.Pos.Line != 2 {
+		t.Errorf("wrong error position. got=%s", err.Pos)
+	}
+}
+
+func TestRelativeImports(t *testing.T) {
+	dir := writeModules(t, map[string]string{
+		"app/main.svo":          "import a from './lib/a.svo';\nimport read from './lib/util/read.svo';\n[a, read(), file('./data.txt')]",
+		"app/data.txt":          "app data",
+		"app/lib/a.svo":         "import b from './util/b.svo'; import top from '../top.svo'; export let a = b + top;",
+		"app/lib/util/b.svo":    "export let b = 'b' + file('../../data.txt');",
+		"app/lib/util/read.svo": "export let read = fn() { file('./data.txt') };",
+		"app/lib/util/data.txt": "util data",
+		"app/top.svo":           "export let top = ' top';",
+	})
+
+	// Paths don't depend on the working directory
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	os.Chdir(os.TempDir())
+
+	evaluated := LoadAndEvalFile(filepath.Join(dir, "app/main.svo"))
+	array, ok := evaluated.(*object.Array)
+	if !ok {
+		t.Fatalf("object is not Array. got=%T (%+v)", evaluated, evaluated)
+	}
+	for i, expected := range []string{"bapp data top", "util data", "app data"} {
+		testStringObject(t, array.Elements[i], expected)
+	}
+}
+
+func TestImportForms(t *testing.T) {
+	dir := writeModules(t, map[string]string{
+		"db.svo": `
+let connections = 0;
+let connect = fn() { connections += 1; connections };
+
+export fn query(q) { connect(); "result of " + q }
+export fn count() { connections }
+export let {host, port = 5432} = {"host": "localhost"};
+export class Conn { let name = "conn"; }
+export let version = 1;
+export fn upgrade() { version += 1; version }
+`,
+	})
+
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"import './db.svo' as db; db.query('x')", "result of x"},
+		{"import './db.svo' as db; db.port", 5432},
+		{"import './db.svo' as db; db.connections", nil},
+		{"import {Conn} from './db.svo'; let c = new Conn(); c.name", "conn"},
+		{"import {query, count} from './db.svo'; let n = count(); query('a'); query('b'); count() - n", 2},
+		{"import './db.svo' as a; import './db.svo' as b; a.extra = 7; b.extra", 7},
+		{"import './db.svo' as db; db.upgrade(); import './db.svo' as db2; [db.version, db2.version]", []int64{1, 1}},
+		{"import {upgrade, version} from './db.svo'; upgrade(); version", 1},
+		{"import {host, port as p} from './db.svo'; p", 5432},
+		{"import {host, port as p} from './db.svo'; host", "localhost"},
+		{"import {query as q} from './db.svo'; q('y')", "result of y"},
+		{"import {query as q} from './db.svo'; query", "identifier not found: query"},
+		{"import {connect} from './db.svo'", "connect is not exported by ./db.svo"},
+		{"import {host, connections as c} from './db.svo'", "connections is not exported by ./db.svo"},
+		{"let Lib = {'map': 1, 'filter': 2}; import {map, filter as f} from 'Lib'; [map, f]", []int64{1, 2}},
+		{"let Lib = {'map': 1}; import 'Lib' as lib; lib.map", 1},
+		{"let Lib = {'map': 1}; import reduce from 'Lib'", "reduce is not exported by Lib"},
+	}
+
+	for _, tt := range tests {
+		// Relative imports in a program without a file start from the working directory
+		evaluated := testEvalInDir(t, dir, tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case []int64:
+			array, ok := evaluated.(*object.Array)
+			if !ok {
+				t.Errorf("object is not Array. got=%T (%+v)", evaluated, evaluated)
+				continue
+			}
+			for i, el := range expected {
+				testIntegerObject(t, array.Elements[i], el)
+			}
+		case string:
+			if errObj, ok := evaluated.(*object.Error); ok {
+				if errObj.Message != expected {
+					t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+				}
+				continue
+			}
+			testStringObject(t, evaluated, expected)
+		case nil:
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
+func testEvalInDir(t *testing.T, dir, input string) object.Object {
+	t.Helper()
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	return testEval(input)
+}
+
+func TestImportedClasses(t *testing.T) {
+	dir := writeModules(t, map[string]string{
+		"lib/c.svo": `
+let greeting = fn(name) { "hello " + name };
+
+export class Reader {
+  let constructor = fn(name) { this.name = name; }
+  let read = fn() { file('./data.txt') }
+  let greet = fn() { greeting(this.name) }
+}
+`,
+		"lib/data.txt": "lib data",
+		"data.txt":     "main data",
+		"main.svo": `
+import {Reader} from './lib/c.svo';
+let greeting = fn(name) { "bye " + name };
+let name = "main";
+let r = new Reader(name);
+let results = [r.read(), r.greet(), file('./data.txt')];
+results`,
+	})
+
+	evaluated := LoadAndEvalFile(filepath.Join(dir, "main.svo"))
+	array, ok := evaluated.(*object.Array)
+	if !ok {
+		t.Fatalf("object is not Array. got=%T (%+v)", evaluated, evaluated)
+	}
+	for i, expected := range []string{"lib data", "hello main", "main data"} {
+		testStringObject(t, array.Elements[i], expected)
+	}
+}
+
+func TestConcurrentImports(t *testing.T) {
+	dir := writeModules(t, map[string]string{
+		"slow.svo": "let i = 0; while (i < 100000) { i += 1; }; export let value = i;",
+		"self.svo": "let f = fn() { import value from '$DIR/self.svo'; value }; export let value = f();",
+	})
+
+	// Like server handlers, every goroutine imports the module inside of a function, the first
+	// one evaluates it and the others wait for it
+	results := make([]object.Object, 8)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = testEval(fmt.Sprintf("let f = fn() { import value from '%s/slow.svo'; value }; f()", dir))
+		}(i)
+	}
+	wg.Wait()
+
+	for _, result := range results {
+		testIntegerObject(t, result, 100000)
+	}
+
+	// A module importing itself while it is evaluated is still a cycle
+	evaluated := testEval(strings.Replace("import value from '$DIR/self.svo'", "$DIR", dir, -1))
+	errObj, ok := evaluated.(*object.Error)
+	if !ok {
+		t.Fatalf("no error object returned. got=%T(%+v)", evaluated, evaluated)
+	}
+	expected := "import cycle: self.svo is imported while it is still being evaluated"
+	if errObj.Message != expected {
+		t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+	}
+}
+
+func TestSilentImports(t *testing.T) {
+	dir := writeModules(t, map[string]string{
+		"noisy.svo": "log('top'); let say = fn(s) { log(s) }; say('loading'); export let shout = fn(s) { log(s) };",
+		"main.svo":  "import {shout} from './noisy.svo'; log('main'); shout('called');",
+	})
+
+	// Only the import is silent, the module's functions log when they are called afterwards
+	path := filepath.Join(dir, "main.svo")
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	program := parser.New(lexer.NewWithFilename(string(src), path)).ParseProgram()
+
+	output := captureStdout(t, func() { RunModule(path, program, object.NewEnvironment()) })
+	if expected := "main\ncalled\n"; output != expected {
+		t.Errorf("wrong output. expected=%q, got=%q", expected, output)
+	}
+}
+
+func captureStdout(t *testing.T, f func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	f()
+	os.Stdout = stdout
+	w.Close()
+
+	out, err := ioutil.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
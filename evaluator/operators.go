@@ -1,10 +1,12 @@
 package evaluator
 
 import (
+	"bytes"
 	"fmt"
 	"math"
 	"strconv"
 
+	"github.com/jumballaya/servo/ast"
 	"github.com/jumballaya/servo/object"
 )
 
@@ -33,16 +35,14 @@ func evalInfixExpression(operator string, left, right object.Object) object.Obje
 		return evalFloatInfixExpression(operator, left, right)
 	case left.Type() == object.STRING_OBJ && right.Type() == object.STRING_OBJ:
 		return evalStringInfixExpression(operator, left, right)
+	case left.Type() == object.BYTES_OBJ && right.Type() == object.BYTES_OBJ:
+		return evalBytesInfixExpression(operator, left, right)
 	case (left.Type() == object.STRING_OBJ || isNumber(left.Type())) && (right.Type() == object.STRING_OBJ || isNumber(right.Type())) && operator == "+":
 		return evalMixStringIntegerInfixExpression(operator, left, right)
 	case operator == "==":
 		return nativeBooleanToBooleanObject(left == right)
 	case operator == "!=":
 		return nativeBooleanToBooleanObject(left != right)
-	case operator == "&&":
-		return evalBooleanInfixExpression(operator, left, right)
-	case operator == "||":
-		return evalBooleanInfixExpression(operator, left, right)
 	case left.Type() != right.Type():
 		return newError("type mismatch: %s %s %s", left.Type(), operator, right.Type())
 	default:
@@ -54,25 +54,31 @@ func isNumber(obj object.ObjectType) bool {
 	return obj == object.INTEGER_OBJ || obj == object.FLOAT_OBJ
 }
 
-// Eval Boolean Infix Expression
-func evalBooleanInfixExpression(operator string, left, right object.Object) object.Object {
-	l, ok := left.(*object.Boolean)
-	if !ok {
-		return newError("type mismatch: %s %s %s", left.Type(), operator, right.Type())
-	}
-	r, ok := right.(*object.Boolean)
-	if !ok {
-		return newError("type mismatch: %s %s %s", left.Type(), operator, right.Type())
+// Eval Logical Expression evaluates `&&`, `||` and `??`. The right side is only evaluated when
+// the left side doesn't decide the result, and the result is whichever operand decided it,
+// e.g. `name || "anonymous"` or `x != null && x.ready()`
+func evalLogicalExpression(node *ast.InfixExpression, env *object.Environment) object.Object {
+	left := Eval(node.Left, env)
+	if isError(left) {
+		return left
 	}
 
-	switch operator {
+	switch node.Operator {
 	case "&&":
-		return nativeBooleanToBooleanObject(l.Value && r.Value)
+		if !isTruthy(left) {
+			return left
+		}
 	case "||":
-		return nativeBooleanToBooleanObject(l.Value || r.Value)
-	default:
-		return newError("type mismatch: %s %s %s", left.Type(), operator, right.Type())
+		if isTruthy(left) {
+			return left
+		}
+	case "??":
+		if left != NULL {
+			return left
+		}
 	}
+
+	return Eval(node.Right, env)
 }
 
 // Eval Bang Operator Expression
@@ -207,6 +213,25 @@ func evalStringInfixExpression(operator string, left, right object.Object) objec
 	}
 }
 
+// Eval Bytes Infix Expression
+func evalBytesInfixExpression(operator string, left, right object.Object) object.Object {
+	leftVal := left.(*object.Bytes).Value
+	rightVal := right.(*object.Bytes).Value
+	switch operator {
+	case "+":
+		value := make([]byte, 0, len(leftVal)+len(rightVal))
+		value = append(value, leftVal...)
+		value = append(value, rightVal...)
+		return &object.Bytes{Value: value}
+	case "==":
+		return nativeBooleanToBooleanObject(bytes.Equal(leftVal, rightVal))
+	case "!=":
+		return nativeBooleanToBooleanObject(!bytes.Equal(leftVal, rightVal))
+	default:
+		return newError("unknown operator: %s %s %s", left.Type(), operator, right.Type())
+	}
+}
+
 func evalMixStringIntegerInfixExpression(operator string, left, right object.Object) object.Object {
 	leftValue := ""
 	rightValue := ""
@@ -25,6 +25,49 @@ func TestBangOperator(t *testing.T) {
 	}
 }
 
+func TestLogicalOperators(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`let name = null; name || "anonymous"`, "anonymous"},
+		{`let name = "ada"; name || "anonymous"`, "ada"},
+		{`"" || "empty"`, "empty"},
+		{`1 && "yes"`, "yes"},
+		{`0 && "yes"`, 0},
+		{`null && "yes"`, nil},
+		{"5 || undefinedThing", 5},
+		{"false && undefinedThing", false},
+		{"let x = null; x != null && x.ready()", false},
+		{`let calls = 0; let f = fn() { calls += 1; true }; false && f(); true || f(); calls`, 0},
+		{`let calls = 0; let f = fn() { calls += 1; true }; true && f(); false || f(); calls`, 2},
+		{"false || null || 3", 3},
+		{"1 && 2 && 3", 3},
+		{"false || undefinedThing", "identifier not found: undefinedThing"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		case string:
+			if errObj, ok := evaluated.(*object.Error); ok {
+				if errObj.Message != expected {
+					t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+				}
+				continue
+			}
+			testStringObject(t, evaluated, expected)
+		case nil:
+			testNullObject(t, evaluated)
+		}
+	}
+}
+
 func TestStringInfixOperations(t *testing.T) {
 	tests := []struct {
 		input    string
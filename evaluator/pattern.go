@@ -0,0 +1,86 @@
+package evaluator
+
+import (
+	"github.com/jumballaya/servo/ast"
+	"github.com/jumballaya/servo/object"
+)
+
+// Bind Pattern destructures a value into the environment following the shape of the pattern.
+// Anything the value doesn't have, like a missing array element or hash key, is bound as
+// null unless the pattern gives it a default. It returns an error when the value doesn't
+// fit the pattern, otherwise nil.
+func bindPattern(pattern ast.Pattern, val object.Object, env *object.Environment) object.Object {
+	switch pattern := pattern.(type) {
+	case *ast.Identifier:
+		env.Set(pattern.Value, val)
+		return nil
+
+	case *ast.DefaultPattern:
+		// Defaults are evaluated in the environment being bound so they can use earlier names
+		if val == NULL {
+			val = Eval(pattern.Default, env)
+			if isError(val) {
+				return val
+			}
+		}
+		return bindPattern(pattern.Target, val, env)
+
+	case *ast.ArrayPattern:
+		array, ok := val.(*object.Array)
+		if !ok {
+			return newError("cannot destructure %s as an array", val.Type())
+		}
+
+		for i, el := range pattern.Elements {
+			var item object.Object = NULL
+			if i < len(array.Elements) {
+				item = array.Elements[i]
+			}
+			if err := bindPattern(el, item, env); err != nil {
+				return err
+			}
+		}
+
+		if pattern.Rest != nil {
+			rest := []object.Object{}
+			if len(pattern.Elements) < len(array.Elements) {
+				rest = append(rest, array.Elements[len(pattern.Elements):]...)
+			}
+			env.Set(pattern.Rest.Value, &object.Array{Elements: rest})
+		}
+		return nil
+
+	case *ast.HashPattern:
+		hash, ok := val.(*object.Hash)
+		if !ok {
+			return newError("cannot destructure %s as a hash", val.Type())
+		}
+
+		used := map[object.HashKey]bool{}
+		for _, entry := range pattern.Entries {
+			key := (&object.String{Value: entry.Key.Value}).HashKey()
+			used[key] = true
+
+			var item object.Object = NULL
+			if pair, ok := hash.Pairs[key]; ok {
+				item = pair.Value
+			}
+			if err := bindPattern(entry.Value, item, env); err != nil {
+				return err
+			}
+		}
+
+		if pattern.Rest != nil {
+			rest := &object.Hash{Pairs: map[object.HashKey]object.HashPair{}}
+			for key, pair := range hash.Pairs {
+				if !used[key] {
+					rest.Pairs[key] = pair
+				}
+			}
+			env.Set(pattern.Rest.Value, rest)
+		}
+		return nil
+	}
+
+	return newError("unknown pattern: %s", pattern.String())
+}
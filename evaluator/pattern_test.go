@@ -0,0 +1,55 @@
+package evaluator
+
+import (
+	"testing"
+
+	"github.com/jumballaya/servo/object"
+)
+
+func TestDestructuring(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"let [a, b] = [1, 2]; a * 10 + b", 12},
+		{"let [a, b, ...rest] = [1, 2, 3, 4]; len(rest) * 100 + rest[0] * 10 + rest[1]", 234},
+		{"let [a, ...rest] = [1]; len(rest)", 0},
+		{"let [a, b] = [1]; b", nil},
+		{"let [a, b = 5] = [1]; a + b", 6},
+		{"let [a, b = a * 2] = [3]; b", 6},
+		{`let {name, age: years} = {"name": "ada", "age": 36}; name`, "ada"},
+		{`let {name, age: years} = {"name": "ada", "age": 36}; years`, 36},
+		{`let {port = 80} = {}; port`, 80},
+		{`let {port = 80} = {"port": 8080}; port`, 8080},
+		{`let {a, ...rest} = {"a": 1, "b": 2, "c": 3}; rest.b + rest.c`, 5},
+		{`let {a, ...rest} = {"a": 1, "b": 2}; rest.a`, nil},
+		{`let {body: {user: {name}}} = {"body": {"user": {"name": "grace"}}}; name`, "grace"},
+		{`let [{x}, [y, z]] = [{"x": 1}, [2, 3]]; x + y + z`, 6},
+		{`let {"content-type": kind} = {"content-type": "json"}; kind`, "json"},
+		{"let f = fn([x, y]) { x + y }; f([3, 4])", 7},
+		{`let f = fn({name}, greeting = "hi") { greeting + " " + name }; f({"name": "bob"})`, "hi bob"},
+		{"let f = fn(a, b = null) { b }; f(1)", nil},
+		{"let [a] = 5;", "cannot destructure INTEGER as an array"},
+		{"let {a} = [1];", "cannot destructure ARRAY as a hash"},
+		{"let f = fn([x]) { x }; f(1)", "cannot destructure INTEGER as an array"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			if errObj, ok := evaluated.(*object.Error); ok {
+				if errObj.Message != expected {
+					t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+				}
+				continue
+			}
+			testStringObject(t, evaluated, expected)
+		case nil:
+			testNullObject(t, evaluated)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package evaluator
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/jumballaya/servo/object"
+)
+
+// Regex Method is a method available on regex objects through dot notation,
+// e.g. `Regex("[0-9]+").test("abc123")`
+type regexMethod func(re *object.Regex, args ...object.Object) object.Object
+
+// Get Regex Method looks up a regex method by name. This is a switch rather than a map
+// because the methods call back into Eval, which would make a map an initialization cycle.
+func getRegexMethod(name string) (regexMethod, bool) {
+	switch name {
+	case "test":
+		return regexTest, true
+	case "match":
+		return regexMatch, true
+	case "find_all":
+		return regexFindAll, true
+	case "replace":
+		return regexReplace, true
+	case "split":
+		return regexSplit, true
+	default:
+		return nil, false
+	}
+}
+
+// New Regex compiles the pattern with the given flags into a regex object. Flags can be any of
+// `i` (case insensitive), `m` (multi-line), `s` (dot matches newlines) and `U` (ungreedy).
+func newRegex(args ...object.Object) object.Object {
+	if len(args) != 1 && len(args) != 2 {
+		return newError("wrong number of arguments. Got: %d. Want: 1 or 2", len(args))
+	}
+
+	pattern, ok := args[0].(*object.String)
+	if !ok {
+		return newError("pattern passed to `Regex` must be STRING, got %s", args[0].Type())
+	}
+
+	flags := ""
+	if len(args) == 2 {
+		f, ok := args[1].(*object.String)
+		if !ok {
+			return newError("flags passed to `Regex` must be STRING, got %s", args[1].Type())
+		}
+		flags = f.Value
+	}
+
+	for _, flag := range flags {
+		if !strings.ContainsRune("imsU", flag) {
+			return newError("unknown regex flag %q", flag)
+		}
+	}
+
+	source := pattern.Value
+	if flags != "" {
+		source = "(?" + flags + ")" + source
+	}
+
+	re, err := regexp.Compile(source)
+	if err != nil {
+		return newError("invalid regex: %s", err)
+	}
+
+	return &object.Regex{Pattern: pattern.Value, Flags: flags, Value: re}
+}
+
+// Bind Regex Method returns the named method bound to the regex as a builtin function
+func bindRegexMethod(re *object.Regex, name string) object.Object {
+	method, ok := getRegexMethod(name)
+	if !ok {
+		return newError("regex has no method %s", name)
+	}
+
+	return &object.Builtin{
+		Fn: func(args ...object.Object) object.Object {
+			return method(re, args...)
+		},
+	}
+}
+
+// Regex Test checks if the string contains a match
+func regexTest(re *object.Regex, args ...object.Object) object.Object {
+	str, err := regexStringArg("test", args)
+	if err != nil {
+		return err
+	}
+	return nativeBooleanToBooleanObject(re.Value.MatchString(str))
+}
+
+// Regex Match returns the first match followed by its capture groups, or null if
+// there isn't a match
+func regexMatch(re *object.Regex, args ...object.Object) object.Object {
+	str, err := regexStringArg("match", args)
+	if err != nil {
+		return err
+	}
+
+	loc := re.Value.FindStringSubmatchIndex(str)
+	if loc == nil {
+		return NULL
+	}
+	return submatchArray(str, loc)
+}
+
+// Regex Find All returns every match. Each match is a string, or an array of the match and
+// its capture groups when the pattern has groups.
+func regexFindAll(re *object.Regex, args ...object.Object) object.Object {
+	str, err := regexStringArg("find_all", args)
+	if err != nil {
+		return err
+	}
+
+	matches := []object.Object{}
+	for _, loc := range re.Value.FindAllStringSubmatchIndex(str, -1) {
+		if re.Value.NumSubexp() == 0 {
+			matches = append(matches, &object.String{Value: str[loc[0]:loc[1]]})
+		} else {
+			matches = append(matches, submatchArray(str, loc))
+		}
+	}
+
+	return &object.Array{Elements: matches}
+}
+
+// Regex Replace replaces every match. The replacement is either a string, which can refer to
+// groups with $1 or ${name}, or a function that is called with the match and its groups and
+// returns the replacement.
+func regexReplace(re *object.Regex, args ...object.Object) object.Object {
+	if len(args) != 2 {
+		return newError("wrong number of arguments to `replace`. Got: %d. Want: 2", len(args))
+	}
+
+	str, ok := args[0].(*object.String)
+	if !ok {
+		return newError("argument to `replace` must be STRING, got %s", args[0].Type())
+	}
+
+	switch repl := args[1].(type) {
+	case *object.String:
+		return &object.String{Value: re.Value.ReplaceAllString(str.Value, repl.Value)}
+	case *object.Function, *object.Builtin:
+		var out strings.Builder
+		last := 0
+
+		for _, loc := range re.Value.FindAllStringSubmatchIndex(str.Value, -1) {
+			groups := submatchArray(str.Value, loc).(*object.Array)
+			result := applyFunction(repl, callbackArgs(repl, groups.Elements))
+			if isError(result) {
+				return result
+			}
+
+			out.WriteString(str.Value[last:loc[0]])
+			out.WriteString(result.Inspect())
+			last = loc[1]
+		}
+		out.WriteString(str.Value[last:])
+
+		return &object.String{Value: out.String()}
+	default:
+		return newError("replacement passed to `replace` must be STRING or FUNCTION, got %s", args[1].Type())
+	}
+}
+
+// Regex Split splits the string around every match
+func regexSplit(re *object.Regex, args ...object.Object) object.Object {
+	str, err := regexStringArg("split", args)
+	if err != nil {
+		return err
+	}
+
+	parts := []object.Object{}
+	for _, part := range re.Value.Split(str, -1) {
+		parts = append(parts, &object.String{Value: part})
+	}
+
+	return &object.Array{Elements: parts}
+}
+
+// Regex String Arg checks that the method was called with a single string argument
+func regexStringArg(method string, args []object.Object) (string, *object.Error) {
+	if len(args) != 1 {
+		return "", newError("wrong number of arguments to `%s`. Got: %d. Want: 1", method, len(args))
+	}
+
+	str, ok := args[0].(*object.String)
+	if !ok {
+		return "", newError("argument to `%s` must be STRING, got %s", method, args[0].Type())
+	}
+
+	return str.Value, nil
+}
+
+// Submatch Array builds an array of the match and its capture groups from their indexes,
+// groups that didn't take part in the match are null
+func submatchArray(str string, loc []int) object.Object {
+	elements := []object.Object{}
+	for i := 0; i < len(loc); i += 2 {
+		if loc[i] < 0 {
+			elements = append(elements, NULL)
+			continue
+		}
+		elements = append(elements, &object.String{Value: str[loc[i]:loc[i+1]]})
+	}
+	return &object.Array{Elements: elements}
+}
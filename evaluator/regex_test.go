@@ -0,0 +1,73 @@
+package evaluator
+
+import (
+	"testing"
+
+	"github.com/jumballaya/servo/object"
+)
+
+func TestRegexMethods(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{`Regex("[0-9]+").test("abc123")`, true},
+		{`Regex("[0-9]+").test("abc")`, false},
+		{`Regex("HELLO", "i").test("hello")`, true},
+		{`Regex("(\w+)@(\w+)").match("mail bob@example now")`, []string{"bob@example", "bob", "example"}},
+		{`Regex("(a)|(b)").match("b")`, []string{"b", "", "b"}},
+		{`Regex("x").match("abc")`, nil},
+		{`Regex("[0-9]+").find_all("a1b22c333")`, []string{"1", "22", "333"}},
+		{`len(Regex("(\w)=(\w)").find_all("a=1 b=2"))`, 2},
+		{`Regex("(\w+)@(\w+)").replace("bob@example", "$2 at $1")`, "example at bob"},
+		{`Regex("[0-9]+").replace("a1b22", fn(m) { len(m) * 10 })`, "a10b20"},
+		{`Regex("(\w)(\d)").replace("a1 b2", fn(m, l, d) { d + l })`, "1a 2b"},
+		{`Regex(",\s*").split("a, b,c")`, []string{"a", "b", "c"}},
+		{`Regex("(")`, "invalid regex: error parsing regexp: missing closing ): `(`"},
+		{`Regex("a", "g")`, "unknown regex flag 'g'"},
+		{`Regex("a").nope("a")`, "regex has no method nope"},
+		{`Regex("a").test(1)`, "argument to `test` must be STRING, got INTEGER"},
+		{`Regex("a").replace("a", fn(m) { m + true })`, "type mismatch: STRING + BOOLEAN"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case bool:
+			testBooleanObject(t, evaluated, expected)
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case nil:
+			testNullObject(t, evaluated)
+		case string:
+			if errObj, ok := evaluated.(*object.Error); ok {
+				if errObj.Message != expected {
+					t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+				}
+				continue
+			}
+			testStringObject(t, evaluated, expected)
+		case []string:
+			array, ok := evaluated.(*object.Array)
+			if !ok {
+				t.Errorf("obj not Array. Got: %T (%+v)", evaluated, evaluated)
+				continue
+			}
+
+			if len(array.Elements) != len(expected) {
+				t.Errorf("wrong num of elements. want=%d, got=%d",
+					len(expected), len(array.Elements))
+				continue
+			}
+
+			for i, expectedElem := range expected {
+				if expectedElem == "" {
+					testNullObject(t, array.Elements[i])
+					continue
+				}
+				testStringObject(t, array.Elements[i], expectedElem)
+			}
+		}
+	}
+}
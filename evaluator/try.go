@@ -0,0 +1,121 @@
+package evaluator
+
+import (
+	"github.com/jumballaya/servo/ast"
+	"github.com/jumballaya/servo/object"
+)
+
+// Eval Try Statement runs the try block. An error coming out of it is turned into an error
+// value and handed to the catch block. The finally block always runs last; if it returns,
+// throws or breaks itself that wins over the result of the other blocks.
+func evalTryStatement(node *ast.TryStatement, env *object.Environment) object.Object {
+	result := Eval(node.Block, env)
+
+	if err, ok := result.(*object.Error); ok && node.Catch != nil {
+		catchEnv := object.NewEnclosedEnvironment(env)
+		if node.CatchParam != nil {
+			catchEnv.Set(node.CatchParam.Value, &object.ErrorValue{Message: err.Message, Kind: errorKind(err.Kind), Pos: err.Pos})
+		}
+		result = Eval(node.Catch, catchEnv)
+	}
+
+	if node.Finally != nil {
+		finally := Eval(node.Finally, env)
+		if finally != nil {
+			switch finally.Type() {
+			case object.RETURN_VALUE_OBJ, object.ERROR_OBJ, object.BREAK_OBJ, object.CONTINUE_OBJ:
+				return finally
+			}
+		}
+	}
+
+	if result == nil {
+		return NULL
+	}
+	return result
+}
+
+// Eval Throw Statement turns the thrown value into an error
+//
+// Strings become the message, hashes can set the `message` and `kind`, and error values
+// are thrown again as they are
+func evalThrowStatement(node *ast.ThrowStatement, env *object.Environment) object.Object {
+	val := Eval(node.Value, env)
+	if isError(val) {
+		return val
+	}
+
+	switch val := val.(type) {
+	case *object.ErrorValue:
+		return &object.Error{Message: val.Message, Kind: val.Kind, Pos: val.Pos}
+	case *object.String:
+		return &object.Error{Message: val.Value, Kind: "Error"}
+	case *object.Hash:
+		err := &object.Error{Message: val.Inspect(), Kind: "Error"}
+		if message, ok := hashStringValue(val, "message"); ok {
+			err.Message = message
+		}
+		if kind, ok := hashStringValue(val, "kind"); ok {
+			err.Kind = kind
+		}
+		return err
+	default:
+		return &object.Error{Message: val.Inspect(), Kind: "Error"}
+	}
+}
+
+// New Error Value is the Error builtin, it makes an error value from a message and an
+// optional kind e.g. `Error("missing user", "NotFound")`
+func newErrorValue(args ...object.Object) object.Object {
+	if len(args) < 1 || len(args) > 2 {
+		return newError("wrong number of arguments. Got: %d. Want: 1 or 2", len(args))
+	}
+
+	message, ok := args[0].(*object.String)
+	if !ok {
+		return newError("argument to `Error` must be STRING, got %s", args[0].Type())
+	}
+
+	kind := "Error"
+	if len(args) == 2 {
+		k, ok := args[1].(*object.String)
+		if !ok {
+			return newError("kind passed to `Error` must be STRING, got %s", args[1].Type())
+		}
+		kind = k.Value
+	}
+
+	return &object.ErrorValue{Message: message.Value, Kind: kind}
+}
+
+// Error Value Field gets the `message` or `kind` of an error value
+func errorValueField(ev *object.ErrorValue, name string) object.Object {
+	switch name {
+	case "message":
+		return &object.String{Value: ev.Message}
+	case "kind":
+		return &object.String{Value: ev.Kind}
+	}
+	return newError("error has no field %s", name)
+}
+
+// Error Kind gives errors made by the interpreter itself the kind RuntimeError
+func errorKind(kind string) string {
+	if kind == "" {
+		return "RuntimeError"
+	}
+	return kind
+}
+
+// Hash String Value gets the string stored in a hash under a string key
+func hashStringValue(hash *object.Hash, key string) (string, bool) {
+	pair, ok := hash.Pairs[(&object.String{Value: key}).HashKey()]
+	if !ok {
+		return "", false
+	}
+	str, ok := pair.Value.(*object.String)
+	if !ok {
+		return "", false
+	}
+	return str.Value, true
+}
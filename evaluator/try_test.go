@@ -0,0 +1,75 @@
+package evaluator
+
+import (
+	"testing"
+
+	"github.com/jumballaya/servo/object"
+)
+
+func TestTryStatement(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"try { 1 } catch (e) { 2 }", 1},
+		{"try { 1 + true } catch (e) { 2 }", 2},
+		{"try { 1 + true } catch (e) { e.message }", "type mismatch: INTEGER + BOOLEAN"},
+		{"try { 1 + true } catch (e) { e.kind }", "RuntimeError"},
+		{"try { throw 'oops' } catch (e) { e.message + ' ' + e.kind }", "oops Error"},
+		{"try { throw Error('no user', 'NotFound') } catch (e) { e.kind }", "NotFound"},
+		{"try { throw {'message': 'bad', 'kind': 'ValueError'} } catch (e) { e.message + e.kind }", "badValueError"},
+		{"let f = fn() { throw 'deep' }; try { f() } catch (e) { e.message }", "deep"},
+		{"try { throw 'x' } catch { 3 }", 3},
+		{"let n = 0; try { n = 1 } finally { n += 10 }; n", 11},
+		{"let n = 0; try { throw 'x' } catch (e) { n = 1 } finally { n += 10 }; n", 11},
+		{"let n = 0; let f = fn() { try { return 1 } finally { n = 5 } }; f() + n", 6},
+		{"let f = fn() { try { return 1 } finally { return 2 } }; f()", 2},
+		{"let n = 0; for (x in [1, 2, 3]) { try { if (x == 2) { break; } } finally { n += 1 } }; n", 2},
+		{"try { throw 'inner' } catch (e) { try { throw e } catch (e2) { e2.message } }", "inner"},
+		{"try { throw 'x' } catch (e) { e.nope }", "error has no field nope"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		switch expected := tt.expected.(type) {
+		case int:
+			testIntegerObject(t, evaluated, int64(expected))
+		case string:
+			if errObj, ok := evaluated.(*object.Error); ok {
+				if errObj.Message != expected {
+					t.Errorf("wrong error message. expected=%q, got=%q", expected, errObj.Message)
+				}
+				continue
+			}
+			testStringObject(t, evaluated, expected)
+		}
+	}
+}
+
+func TestUncaughtErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"throw 'oops'", "Error: 1:1: oops"},
+		{"let f = fn() {\n  throw Error('bad input', 'ValueError')\n};\nf()", "ValueError: 2:3: bad input"},
+		{"try { throw 'first' } catch (e) {\n  throw e\n}", "Error: 1:7: first"},
+		{"try { 1 } finally { throw 'late' }", "Error: 1:21: late"},
+		{"try { throw 'x' } finally { 1 }", "Error: 1:7: x"},
+	}
+
+	for _, tt := range tests {
+		evaluated := testEval(tt.input)
+
+		errObj, ok := evaluated.(*object.Error)
+		if !ok {
+			t.Errorf("no error object returned. got=%T(%+v)", evaluated, evaluated)
+			continue
+		}
+
+		if errObj.Inspect() != tt.expected {
+			t.Errorf("wrong error. expected=%q, got=%q", tt.expected, errObj.Inspect())
+		}
+	}
+}
@@ -1,28 +1,66 @@
 package lexer
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/jumballaya/servo/token"
 )
 
 type Lexer struct {
 	input        string
-	position     int  // current position (current)
-	readPosition int  // current reading position (after current)
-	ch           byte // current char
+	filename     string
+	position     int  // current byte position (current)
+	readPosition int  // current byte reading position (after current)
+	ch           rune // current char
+	line         int  // line of the current char
+	column       int  // column of the current char, counted in runes
+
+	// Brace depth inside of each open template substitution `${ ... }`, innermost last
+	templates []int
 }
 
+// New creates a lexer for source code that doesn't come from a file, like the REPL
 func New(input string) *Lexer {
-	l := &Lexer{input: input}
+	return NewWithFilename(input, "")
+}
+
+// NewWithFilename creates a lexer that tags every token position with the file name
+func NewWithFilename(input, filename string) *Lexer {
+	l := &Lexer{input: input, filename: filename, line: 1}
 	l.readChar()
 	return l
 }
 
+// Copy returns a lexer at the same position, reading from it doesn't move this one. The parser
+// uses it to look ahead more than one token.
+func (l *Lexer) Copy() *Lexer {
+	c := *l
+	c.templates = append([]int{}, l.templates...)
+	return &c
+}
+
+// Input returns the source code being lexed
+func (l *Lexer) Input() string {
+	return l.input
+}
+
+// Filename returns the name of the file being lexed
+func (l *Lexer) Filename() string {
+	return l.filename
+}
+
 func (l *Lexer) NextToken() token.Token {
 	var tok token.Token
 
-	l.skipWhiteSpace()
+	if pos, err := l.skipWhiteSpace(); err != nil {
+		return token.Token{Type: token.ILLEGAL, Literal: err.Error(), Pos: pos}
+	}
+	pos := l.pos()
 
 	switch l.ch {
 	case '=':
@@ -31,6 +69,11 @@ func (l *Lexer) NextToken() token.Token {
 			l.readChar()
 			literal := string(ch) + string(l.ch)
 			tok = token.Token{Type: token.EQ, Literal: literal}
+		} else if l.peekChar() == '>' {
+			ch := l.ch
+			l.readChar()
+			literal := string(ch) + string(l.ch)
+			tok = token.Token{Type: token.ARROW, Literal: literal}
 		} else {
 			tok = newToken(token.ASSIGN, l.ch)
 		}
@@ -80,7 +123,14 @@ func (l *Lexer) NextToken() token.Token {
 			tok = newToken(token.ASTERISK, l.ch)
 		}
 	case '%':
-		tok = newToken(token.MODULO, l.ch)
+		if l.peekChar() == '=' {
+			ch := l.ch
+			l.readChar()
+			literal := string(ch) + string(l.ch)
+			tok = token.Token{Type: token.MODULOASSIGN, Literal: literal}
+		} else {
+			tok = newToken(token.MODULO, l.ch)
+		}
 	case '<':
 		switch l.peekChar() {
 		case '=':
@@ -93,6 +143,10 @@ func (l *Lexer) NextToken() token.Token {
 			l.readChar()
 			literal := string(ch) + string(l.ch)
 			tok = token.Token{Type: token.SHIFTLEFT, Literal: literal}
+			if l.peekChar() == '=' {
+				l.readChar()
+				tok = token.Token{Type: token.SHIFTLEFTASSIGN, Literal: literal + string(l.ch)}
+			}
 		default:
 			tok = newToken(token.LT, l.ch)
 		}
@@ -108,6 +162,10 @@ func (l *Lexer) NextToken() token.Token {
 			l.readChar()
 			literal := string(ch) + string(l.ch)
 			tok = token.Token{Type: token.SHIFTRIGHT, Literal: literal}
+			if l.peekChar() == '=' {
+				l.readChar()
+				tok = token.Token{Type: token.SHIFTRIGHTASSIGN, Literal: literal + string(l.ch)}
+			}
 		default:
 			tok = newToken(token.GT, l.ch)
 		}
@@ -117,6 +175,11 @@ func (l *Lexer) NextToken() token.Token {
 			l.readChar()
 			literal := string(ch) + string(l.ch)
 			tok = token.Token{Type: token.OR, Literal: literal}
+		} else if l.peekChar() == '=' {
+			ch := l.ch
+			l.readChar()
+			literal := string(ch) + string(l.ch)
+			tok = token.Token{Type: token.BITWISEORASSIGN, Literal: literal}
 		} else {
 			tok = newToken(token.BITWISEOR, l.ch)
 		}
@@ -131,6 +194,11 @@ func (l *Lexer) NextToken() token.Token {
 			l.readChar()
 			literal := string(ch) + string(l.ch)
 			tok = token.Token{Type: token.AND, Literal: literal}
+		} else if l.peekChar() == '=' {
+			ch := l.ch
+			l.readChar()
+			literal := string(ch) + string(l.ch)
+			tok = token.Token{Type: token.BITWISEANDASSIGN, Literal: literal}
 		} else {
 			tok = newToken(token.BITWISEAND, l.ch)
 		}
@@ -138,24 +206,72 @@ func (l *Lexer) NextToken() token.Token {
 		tok = newToken(token.SEMICOLON, l.ch)
 	case ',':
 		tok = newToken(token.COMMA, l.ch)
+	case '?':
+		switch l.peekChar() {
+		case '?':
+			ch := l.ch
+			l.readChar()
+			literal := string(ch) + string(l.ch)
+			tok = token.Token{Type: token.COALESCE, Literal: literal}
+		case '.':
+			ch := l.ch
+			l.readChar()
+			literal := string(ch) + string(l.ch)
+			tok = token.Token{Type: token.QDOT, Literal: literal}
+		default:
+			tok = newToken(token.QMARK, l.ch)
+		}
 	case '.':
-		tok = newToken(token.DOT, l.ch)
+		if strings.HasPrefix(l.input[l.readPosition:], "..") {
+			l.readChar()
+			l.readChar()
+			tok = token.Token{Type: token.ELLIPSIS, Literal: "..."}
+		} else if l.peekChar() == '.' {
+			l.readChar()
+			tok = token.Token{Type: token.DOTDOT, Literal: ".."}
+		} else {
+			tok = newToken(token.DOT, l.ch)
+		}
 	case '^':
-		tok = newToken(token.CARROT, l.ch)
+		if l.peekChar() == '=' {
+			ch := l.ch
+			l.readChar()
+			literal := string(ch) + string(l.ch)
+			tok = token.Token{Type: token.CARROTASSIGN, Literal: literal}
+		} else {
+			tok = newToken(token.CARROT, l.ch)
+		}
 	case '(':
 		tok = newToken(token.LPAREN, l.ch)
 	case ')':
 		tok = newToken(token.RPAREN, l.ch)
 	case '{':
+		if len(l.templates) > 0 {
+			l.templates[len(l.templates)-1]++
+		}
 		tok = newToken(token.LBRACE, l.ch)
 	case '}':
-		tok = newToken(token.RBRACE, l.ch)
-	case '"':
-		tok.Type = token.STRING
-		tok.Literal = l.readString('"')
-	case '\'':
-		tok.Type = token.STRING
-		tok.Literal = l.readString('\'')
+		if len(l.templates) > 0 && l.templates[len(l.templates)-1] == 0 {
+			// Closes a template substitution, keep reading the template text
+			l.templates = l.templates[:len(l.templates)-1]
+			tok = l.readTemplateToken(token.TEMPLATE_MIDDLE, token.TEMPLATE_TAIL)
+		} else {
+			if len(l.templates) > 0 {
+				l.templates[len(l.templates)-1]--
+			}
+			tok = newToken(token.RBRACE, l.ch)
+		}
+	case '`':
+		tok = l.readTemplateToken(token.TEMPLATE_HEAD, token.TEMPLATE)
+	case '"', '\'':
+		str, err := l.readString(l.ch)
+		if err != nil {
+			tok.Type = token.ILLEGAL
+			tok.Literal = err.Error()
+		} else {
+			tok.Type = token.STRING
+			tok.Literal = str
+		}
 	case '[':
 		tok = newToken(token.LBRACKET, l.ch)
 	case ']':
@@ -173,37 +289,68 @@ func (l *Lexer) NextToken() token.Token {
 		tok.Literal = ""
 		tok.Type = token.EOF
 	case '#':
-		tok.Type = token.COMMENT
-		tok.Literal = l.readString('\n')
+		// Only doc comments make it here, other comments are skipped with the whitespace
+		tok.Type = token.DOC_COMMENT
+		tok.Literal = l.readDocComment()
 	default:
+		if l.ch == 'b' && (l.peekChar() == '"' || l.peekChar() == '\'') {
+			l.readChar()
+			str, err := l.readString(l.ch)
+			if err != nil {
+				tok.Type = token.ILLEGAL
+				tok.Literal = err.Error()
+			} else {
+				tok.Type = token.BYTES
+				tok.Literal = str
+			}
+			break
+		}
+
 		if isLetter(l.ch) {
 			tok.Literal = l.readIdentifier()
 			tok.Type = token.LookupIdent(tok.Literal)
+			tok.Pos = pos
 			return tok
 		} else if isDigit(l.ch) {
-			tok.Literal = l.readNumber()
-			if isFloat(tok.Literal) {
-				tok.Type = token.FLOAT
-			} else {
-				tok.Type = token.INT
-			}
+			tok.Type, tok.Literal = l.readNumber()
+			tok.Pos = pos
 			return tok
 		}
-		tok = newToken(token.ILLEGAL, l.ch)
+		tok.Type = token.ILLEGAL
+		tok.Literal = fmt.Sprintf("illegal character %q", l.ch)
 	}
 
+	tok.Pos = pos
 	l.readChar()
 	return tok
 }
 
 func (l *Lexer) readChar() {
+	if l.ch == '\n' {
+		l.line++
+		l.column = 0
+	}
+
+	l.position = l.readPosition
 	if l.readPosition >= len(l.input) {
 		l.ch = 0
+		l.readPosition++
 	} else {
-		l.ch = l.input[l.readPosition]
+		ch, width := utf8.DecodeRuneInString(l.input[l.readPosition:])
+		l.ch = ch
+		l.readPosition += width
+	}
+	l.column++
+}
+
+// Pos returns the position of the current char
+func (l *Lexer) pos() token.Position {
+	return token.Position{
+		Filename: l.filename,
+		Offset:   l.position,
+		Line:     l.line,
+		Column:   l.column,
 	}
-	l.position = l.readPosition
-	l.readPosition++
 }
 
 func (l *Lexer) readIdentifier() string {
@@ -214,64 +361,272 @@ func (l *Lexer) readIdentifier() string {
 	return l.input[position:l.position]
 }
 
-func (l *Lexer) readNumber() string {
+// Read Number reads integer and float literals. Integers can be written in decimal, hex (0xff),
+// octal (0o755) or binary (0b1010) and floats can have an exponent (1.5e-3). Any number can
+// use underscores as digit separators (1_000_000).
+//
+// The literal isn't validated here: anything that looks like the start of a number is read as
+// a single token so the parser can report malformed numbers like `1.2.3` or `0b102` as a whole.
+func (l *Lexer) readNumber() (token.TokenType, string) {
 	position := l.position
-	for isDigit(l.ch) || l.ch == '.' {
+	var tokenType token.TokenType = token.INT
+
+	if l.ch == '0' && strings.ContainsRune("xXoObB", l.peekChar()) {
+		l.readChar()
+		l.readChar()
+	} else {
+		l.readDigits()
+		for l.ch == '.' && isDigit(l.peekChar()) {
+			tokenType = token.FLOAT
+			l.readChar()
+			l.readDigits()
+		}
+		if l.ch == 'e' || l.ch == 'E' {
+			tokenType = token.FLOAT
+			l.readChar()
+			if l.ch == '+' || l.ch == '-' {
+				l.readChar()
+			}
+		}
+	}
+
+	for isLetter(l.ch) || isDigit(l.ch) {
 		l.readChar()
 	}
-	return l.input[position:l.position]
+
+	return tokenType, l.input[position:l.position]
 }
 
-func (l *Lexer) readString(initial byte) string {
-	position := l.position + 1
-	if initial == '\'' || initial == '"' {
-		for {
-			l.readChar()
-			if l.ch == '\'' || l.ch == '"' || l.ch == 0 {
-				break
+func (l *Lexer) readDigits() {
+	for isDigit(l.ch) || l.ch == '_' {
+		l.readChar()
+	}
+}
+
+// Read String reads a string literal up to its matching quote, decoding escape sequences along
+// the way. The lexer is left on the closing quote. Unknown escape sequences are kept as they are
+// so patterns like "\d+" don't need double escaping.
+func (l *Lexer) readString(quote rune) (string, error) {
+	var out strings.Builder
+
+	for {
+		l.readChar()
+
+		switch l.ch {
+		case quote:
+			return out.String(), nil
+		case 0:
+			return "", errors.New("unterminated string literal")
+		case '\\':
+			if err := l.readEscape(&out); err != nil {
+				return "", err
 			}
+		default:
+			out.WriteString(l.input[l.position:l.readPosition])
 		}
-		return l.input[position:l.position]
-	} else {
-		for {
+	}
+}
+
+// Read Template Token reads the template text after the current '`' or '}'. The token is of
+// the open type when the text ends with a `${` substitution and the closed type when it ends
+// with the closing backtick.
+func (l *Lexer) readTemplateToken(open, closed token.TokenType) token.Token {
+	var out strings.Builder
+
+	for {
+		l.readChar()
+
+		switch {
+		case l.ch == '`':
+			return token.Token{Type: closed, Literal: out.String()}
+		case l.ch == '$' && l.peekChar() == '{':
 			l.readChar()
-			if l.ch == initial || l.ch == 0 {
-				break
+			l.templates = append(l.templates, 0)
+			return token.Token{Type: open, Literal: out.String()}
+		case l.ch == 0:
+			return token.Token{Type: token.ILLEGAL, Literal: "unterminated template literal"}
+		case l.ch == '\\':
+			if err := l.readEscape(&out); err != nil {
+				return token.Token{Type: token.ILLEGAL, Literal: err.Error()}
 			}
+		default:
+			out.WriteString(l.input[l.position:l.readPosition])
 		}
-		return l.input[position:l.position]
 	}
 }
 
-func (l *Lexer) skipWhiteSpace() {
-	for l.ch == ' ' || l.ch == '\t' || l.ch == '\n' || l.ch == '\r' {
+// Read Escape decodes the escape sequence starting at the current backslash
+func (l *Lexer) readEscape(out *strings.Builder) error {
+	l.readChar()
+
+	switch l.ch {
+	case 'n':
+		out.WriteByte('\n')
+	case 't':
+		out.WriteByte('\t')
+	case 'r':
+		out.WriteByte('\r')
+	case 'b':
+		out.WriteByte('\b')
+	case 'f':
+		out.WriteByte('\f')
+	case 'v':
+		out.WriteByte('\v')
+	case '0':
+		out.WriteByte(0)
+	case '\\', '\'', '"', '`', '$':
+		out.WriteRune(l.ch)
+	case 'x':
+		value, err := l.readHexDigits(2)
+		if err != nil {
+			return fmt.Errorf("invalid escape sequence \\x: %s", err)
+		}
+		out.WriteByte(byte(value))
+	case 'u':
+		var value int64
+		var err error
+		if l.peekChar() == '{' {
+			l.readChar()
+			value, err = l.readHexDigitsUntil('}')
+		} else {
+			value, err = l.readHexDigits(4)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid escape sequence \\u: %s", err)
+		}
+		if value > unicode.MaxRune || (value >= 0xD800 && value <= 0xDFFF) {
+			return fmt.Errorf("invalid escape sequence \\u: %X is not a valid code point", value)
+		}
+		out.WriteRune(rune(value))
+	case 0:
+		return errors.New("unterminated string literal")
+	default:
+		out.WriteByte('\\')
+		out.WriteString(l.input[l.position:l.readPosition])
+	}
+
+	return nil
+}
+
+// Read Hex Digits reads exactly n hex digits following the current char
+func (l *Lexer) readHexDigits(n int) (int64, error) {
+	start := l.readPosition
+	for i := 0; i < n; i++ {
+		if !isHexDigit(l.peekChar()) {
+			return 0, fmt.Errorf("expected %d hex digits", n)
+		}
+		l.readChar()
+	}
+	return strconv.ParseInt(l.input[start:l.readPosition], 16, 64)
+}
+
+// Read Hex Digits Until reads between 1 and 6 hex digits followed by the end char
+func (l *Lexer) readHexDigitsUntil(end rune) (int64, error) {
+	start := l.readPosition
+	for isHexDigit(l.peekChar()) {
 		l.readChar()
 	}
+	digits := l.input[start:l.readPosition]
+	if l.peekChar() != end || len(digits) == 0 || len(digits) > 6 {
+		return 0, errors.New("expected 1 to 6 hex digits between braces")
+	}
+	l.readChar()
+	return strconv.ParseInt(digits, 16, 64)
+}
+
+// Read Comment reads everything up to the end of the line
+func (l *Lexer) readComment() string {
+	position := l.position + 1
+	for {
+		l.readChar()
+		if l.ch == '\n' || l.ch == 0 {
+			break
+		}
+	}
+	return l.input[position:l.position]
+}
+
+// Read Doc Comment reads a `##` comment, dropping the markers and the space after them
+func (l *Lexer) readDocComment() string {
+	l.readChar()
+	return strings.TrimPrefix(l.readComment(), " ")
+}
+
+// Is Doc Comment checks if the current char starts a `##` doc comment. Lines starting with three
+// or more #'s are banners, not doc comments.
+func (l *Lexer) isDocComment() bool {
+	return l.ch == '#' && l.peekChar() == '#' &&
+		(l.readPosition+1 >= len(l.input) || l.input[l.readPosition+1] != '#')
 }
 
-func (l *Lexer) peekChar() byte {
+// Skip Block Comment skips a `/* ... */` comment, which can be nested. It returns the position
+// of the comment if it is never closed.
+func (l *Lexer) skipBlockComment() (token.Position, error) {
+	pos := l.pos()
+	depth := 0
+
+	for {
+		switch {
+		case l.ch == 0:
+			return pos, errors.New("unterminated block comment")
+		case l.ch == '/' && l.peekChar() == '*':
+			depth++
+			l.readChar()
+		case l.ch == '*' && l.peekChar() == '/':
+			depth--
+			l.readChar()
+			if depth == 0 {
+				l.readChar()
+				return pos, nil
+			}
+		}
+		l.readChar()
+	}
+}
+
+// Skip White Space skips whitespace along with `#` line comments and `/* */` block comments so
+// they never reach the parser
+func (l *Lexer) skipWhiteSpace() (token.Position, error) {
+	for {
+		switch {
+		case l.ch == ' ' || l.ch == '\t' || l.ch == '\n' || l.ch == '\r':
+			l.readChar()
+		case l.ch == '#' && !l.isDocComment():
+			l.readComment()
+		case l.ch == '/' && l.peekChar() == '*':
+			if pos, err := l.skipBlockComment(); err != nil {
+				return pos, err
+			}
+		default:
+			return token.Position{}, nil
+		}
+	}
+}
+
+func (l *Lexer) peekChar() rune {
 	if l.readPosition >= len(l.input) {
 		return 0
 	}
-	return l.input[l.readPosition]
+	ch, _ := utf8.DecodeRuneInString(l.input[l.readPosition:])
+	return ch
 }
 
-func newToken(tokenType token.TokenType, ch byte) token.Token {
+func newToken(tokenType token.TokenType, ch rune) token.Token {
 	return token.Token{Type: tokenType, Literal: string(ch)}
 }
 
-func isLetter(ch byte) bool {
-	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_' || ch == '\''
+func isLetter(ch rune) bool {
+	return unicode.IsLetter(ch) || ch == '_' || ch == '\''
 }
 
-func isIdent(ch byte) bool {
-	return isLetter(ch) || (ch != '.' && isDigit(ch))
+func isIdent(ch rune) bool {
+	return isLetter(ch) || unicode.IsDigit(ch) || unicode.Is(unicode.Mn, ch)
 }
 
-func isDigit(ch byte) bool {
-	return '0' <= ch && ch <= '9'
+func isHexDigit(ch rune) bool {
+	return isDigit(ch) || 'a' <= ch && ch <= 'f' || 'A' <= ch && ch <= 'F'
 }
 
-func isFloat(num string) bool {
-	return strings.Contains(num, ".") && num[len(num)-1:] != "."
+func isDigit(ch rune) bool {
+	return '0' <= ch && ch <= '9'
 }
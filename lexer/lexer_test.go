@@ -24,7 +24,7 @@ let add = fn(x, y) {
 };
 
 let result = add(five, ten);
-!-/*5;
+!-/ *5;
 5 < 10 > 5;
 
 if (5 < 10) {
@@ -59,6 +59,12 @@ null;
 5 -= 5;
 5 *= 5;
 5 /= 5;
+5 %= 5;
+5 ^= 5;
+5 |= 5;
+5 &= 5;
+5 <<= 5;
+5 >>= 5;
 5 ^ 2;
 true && false;
 true || false;
@@ -189,8 +195,6 @@ e instanceof Example;
 		{token.STRING, "bar"},
 		{token.RBRACE, "}"},
 		{token.SEMICOLON, ";"},
-		{token.COMMENT, "This is a comment"},
-		{token.COMMENT, `'This is a comment with quotes"`},
 		{token.INT, "5"},
 		{token.LTE, "<="},
 		{token.INT, "10"},
@@ -242,6 +246,30 @@ e instanceof Example;
 		{token.INT, "5"},
 		{token.SEMICOLON, ";"},
 		{token.INT, "5"},
+		{token.MODULOASSIGN, "%="},
+		{token.INT, "5"},
+		{token.SEMICOLON, ";"},
+		{token.INT, "5"},
+		{token.CARROTASSIGN, "^="},
+		{token.INT, "5"},
+		{token.SEMICOLON, ";"},
+		{token.INT, "5"},
+		{token.BITWISEORASSIGN, "|="},
+		{token.INT, "5"},
+		{token.SEMICOLON, ";"},
+		{token.INT, "5"},
+		{token.BITWISEANDASSIGN, "&="},
+		{token.INT, "5"},
+		{token.SEMICOLON, ";"},
+		{token.INT, "5"},
+		{token.SHIFTLEFTASSIGN, "<<="},
+		{token.INT, "5"},
+		{token.SEMICOLON, ";"},
+		{token.INT, "5"},
+		{token.SHIFTRIGHTASSIGN, ">>="},
+		{token.INT, "5"},
+		{token.SEMICOLON, ";"},
+		{token.INT, "5"},
 		{token.CARROT, "^"},
 		{token.INT, "2"},
 		{token.SEMICOLON, ";"},
@@ -306,3 +334,389 @@ e instanceof Example;
 		}
 	}
 }
+
+func TestTokenPositions(t *testing.T) {
+	input := "let x = 5;\n  x + 10;"
+
+	tests := []struct {
+		expectedType token.TokenType
+		line         int
+		column       int
+		offset       int
+	}{
+		{token.LET, 1, 1, 0},
+		{token.IDENT, 1, 5, 4},
+		{token.ASSIGN, 1, 7, 6},
+		{token.INT, 1, 9, 8},
+		{token.SEMICOLON, 1, 10, 9},
+		{token.IDENT, 2, 3, 13},
+		{token.PLUS, 2, 5, 15},
+		{token.INT, 2, 7, 17},
+		{token.SEMICOLON, 2, 9, 19},
+		{token.EOF, 2, 10, 20},
+	}
+
+	l := NewWithFilename(input, "main.svo")
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q",
+				i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Pos.Filename != "main.svo" {
+			t.Fatalf("tests[%d] - filename wrong. expected=%q, got=%q",
+				i, "main.svo", tok.Pos.Filename)
+		}
+
+		if tok.Pos.Line != tt.line || tok.Pos.Column != tt.column || tok.Pos.Offset != tt.offset {
+			t.Fatalf("tests[%d] - position wrong. expected=%d:%d (offset %d), got=%d:%d (offset %d)",
+				i, tt.line, tt.column, tt.offset, tok.Pos.Line, tok.Pos.Column, tok.Pos.Offset)
+		}
+	}
+}
+
+func TestUnicodeInput(t *testing.T) {
+	input := `let café = "héllo 👋"; # ünïcödé comment
+名前 + ñ;`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+		column          int
+	}{
+		{token.LET, "let", 1},
+		{token.IDENT, "café", 5},
+		{token.ASSIGN, "=", 10},
+		{token.STRING, "héllo 👋", 12},
+		{token.SEMICOLON, ";", 21},
+		{token.IDENT, "名前", 1},
+		{token.PLUS, "+", 4},
+		{token.IDENT, "ñ", 6},
+		{token.SEMICOLON, ";", 7},
+		{token.EOF, "", 8},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q",
+				i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q",
+				i, tt.expectedLiteral, tok.Literal)
+		}
+
+		if tok.Pos.Column != tt.column {
+			t.Fatalf("tests[%d] - column wrong. expected=%d, got=%d",
+				i, tt.column, tok.Pos.Column)
+		}
+	}
+}
+
+func TestNumberLiterals(t *testing.T) {
+	input := `0xff 0o17 0b1010 1_000 3.14 1.5e-3 2E8 1.2.3 arr.0 5.foo`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.INT, "0xff"},
+		{token.INT, "0o17"},
+		{token.INT, "0b1010"},
+		{token.INT, "1_000"},
+		{token.FLOAT, "3.14"},
+		{token.FLOAT, "1.5e-3"},
+		{token.FLOAT, "2E8"},
+		{token.FLOAT, "1.2.3"},
+		{token.IDENT, "arr"},
+		{token.DOT, "."},
+		{token.INT, "0"},
+		{token.INT, "5"},
+		{token.DOT, "."},
+		{token.IDENT, "foo"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q",
+				i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q",
+				i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestStringEscapes(t *testing.T) {
+	tests := []struct {
+		input           string
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{`"it's"`, token.STRING, "it's"},
+		{`'say "hi"'`, token.STRING, `say "hi"`},
+		{`"say \"hi\""`, token.STRING, `say "hi"`},
+		{`'it\'s'`, token.STRING, "it's"},
+		{`"a\\b"`, token.STRING, `a\b`},
+		{`"line\nbreak\ttab\rreturn"`, token.STRING, "line\nbreak\ttab\rreturn"},
+		{`"nul\0"`, token.STRING, "nul\x00"},
+		{`"\x41\x62"`, token.STRING, "Ab"},
+		{`"é"`, token.STRING, "é"},
+		{`"\u{1F44B}"`, token.STRING, "👋"},
+		{`"\d+"`, token.STRING, `\d+`},
+		{`"unterminated`, token.ILLEGAL, "unterminated string literal"},
+		{`'mismatched"`, token.ILLEGAL, "unterminated string literal"},
+		{`"\xZZ"`, token.ILLEGAL, `invalid escape sequence \x: expected 2 hex digits`},
+		{`"\u{110000}"`, token.ILLEGAL, `invalid escape sequence \u: 110000 is not a valid code point`},
+		{`"\u{}"`, token.ILLEGAL, `invalid escape sequence \u: expected 1 to 6 hex digits between braces`},
+	}
+
+	for i, tt := range tests {
+		tok := New(tt.input).NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q",
+				i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q",
+				i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestComments(t *testing.T) {
+	input := `# line comment
+let x = 5; # trailing comment
+/* block
+   comment */
+x /* inline */ + /* nested /* block */ comment */ 1;
+###
+## Adds two numbers
+##   together
+let add = 1;
+"# not a comment";
+5 / 2;
+/* never closed`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.LET, "let"},
+		{token.IDENT, "x"},
+		{token.ASSIGN, "="},
+		{token.INT, "5"},
+		{token.SEMICOLON, ";"},
+		{token.IDENT, "x"},
+		{token.PLUS, "+"},
+		{token.INT, "1"},
+		{token.SEMICOLON, ";"},
+		{token.DOC_COMMENT, "Adds two numbers"},
+		{token.DOC_COMMENT, "  together"},
+		{token.LET, "let"},
+		{token.IDENT, "add"},
+		{token.ASSIGN, "="},
+		{token.INT, "1"},
+		{token.SEMICOLON, ";"},
+		{token.STRING, "# not a comment"},
+		{token.SEMICOLON, ";"},
+		{token.INT, "5"},
+		{token.SLASH, "/"},
+		{token.INT, "2"},
+		{token.SEMICOLON, ";"},
+		{token.ILLEGAL, "unterminated block comment"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q",
+				i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q",
+				i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestTemplateLiterals(t *testing.T) {
+	input := "`plain` `a${x}b${ {\"k\": 1}[\"k\"] }c` `${`in${y}`}` `\\${x}\\``"
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.TEMPLATE, "plain"},
+		{token.TEMPLATE_HEAD, "a"},
+		{token.IDENT, "x"},
+		{token.TEMPLATE_MIDDLE, "b"},
+		{token.LBRACE, "{"},
+		{token.STRING, "k"},
+		{token.COLON, ":"},
+		{token.INT, "1"},
+		{token.RBRACE, "}"},
+		{token.LBRACKET, "["},
+		{token.STRING, "k"},
+		{token.RBRACKET, "]"},
+		{token.TEMPLATE_TAIL, "c"},
+		{token.TEMPLATE_HEAD, ""},
+		{token.TEMPLATE_HEAD, "in"},
+		{token.IDENT, "y"},
+		{token.TEMPLATE_TAIL, ""},
+		{token.TEMPLATE_TAIL, ""},
+		{token.TEMPLATE, "${x}`"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q",
+				i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q",
+				i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestBytesLiterals(t *testing.T) {
+	input := `b'hi' b"\x00\xff" bytes b + 'x'`
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.BYTES, "hi"},
+		{token.BYTES, "\x00\xff"},
+		{token.IDENT, "bytes"},
+		{token.IDENT, "b"},
+		{token.PLUS, "+"},
+		{token.STRING, "x"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q",
+				i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q",
+				i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestQuestionMarkOperators(t *testing.T) {
+	input := "a ? b : c ?? d?.e ...f"
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.IDENT, "a"},
+		{token.QMARK, "?"},
+		{token.IDENT, "b"},
+		{token.COLON, ":"},
+		{token.IDENT, "c"},
+		{token.COALESCE, "??"},
+		{token.IDENT, "d"},
+		{token.QDOT, "?."},
+		{token.IDENT, "e"},
+		{token.ELLIPSIS, "..."},
+		{token.IDENT, "f"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q",
+				i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q",
+				i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
+
+func TestMatchTokens(t *testing.T) {
+	input := "match (x) { 1..5 => y, _ => z }"
+
+	tests := []struct {
+		expectedType    token.TokenType
+		expectedLiteral string
+	}{
+		{token.MATCH, "match"},
+		{token.LPAREN, "("},
+		{token.IDENT, "x"},
+		{token.RPAREN, ")"},
+		{token.LBRACE, "{"},
+		{token.INT, "1"},
+		{token.DOTDOT, ".."},
+		{token.INT, "5"},
+		{token.ARROW, "=>"},
+		{token.IDENT, "y"},
+		{token.COMMA, ","},
+		{token.IDENT, "_"},
+		{token.ARROW, "=>"},
+		{token.IDENT, "z"},
+		{token.RBRACE, "}"},
+		{token.EOF, ""},
+	}
+
+	l := New(input)
+
+	for i, tt := range tests {
+		tok := l.NextToken()
+
+		if tok.Type != tt.expectedType {
+			t.Fatalf("tests[%d] - tokentype wrong. expected=%q, got=%q",
+				i, tt.expectedType, tok.Type)
+		}
+
+		if tok.Literal != tt.expectedLiteral {
+			t.Fatalf("tests[%d] - literal wrong. expected=%q, got=%q",
+				i, tt.expectedLiteral, tok.Literal)
+		}
+	}
+}
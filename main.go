@@ -25,5 +25,8 @@ func run(fromFile bool, config *repl.Config) {
 
 func main() {
 	config := &repl.Config{Verbose: true}
+	if len(os.Args) > 1 {
+		config.Filename = os.Args[1]
+	}
 	run(len(os.Args) > 1, config)
 }
@@ -16,6 +16,7 @@ type Class struct {
 	Parent  *Class
 	Fields  []*ast.LetStatement
 	Methods map[string]ClassMethod
+	Env     *Environment // where the class was declared, instances are enclosed by it
 }
 
 func (c *Class) Inspect() string  { return "class " + c.Name }
@@ -3,6 +3,7 @@ package object
 func NewEnclosedEnvironment(outer *Environment) *Environment {
 	env := NewEnvironment()
 	env.outer = outer
+	env.File = outer.File
 	return env
 }
 
@@ -14,7 +15,8 @@ func NewEnvironment() *Environment {
 type Environment struct {
 	store  map[string]Object
 	outer  *Environment
-	Silent bool
+	Silent bool   // `log` does nothing in the environment or any environment it encloses
+	File   string // absolute path of the file the environment belongs to, empty outside of one
 }
 
 func (e *Environment) Get(name string) (Object, bool) {
@@ -25,11 +27,34 @@ func (e *Environment) Get(name string) (Object, bool) {
 	return obj, ok
 }
 
+// Is Silent checks if the environment or any environment enclosing it is silent
+func (e *Environment) IsSilent() bool {
+	for ; e != nil; e = e.outer {
+		if e.Silent {
+			return true
+		}
+	}
+	return false
+}
+
 func (e *Environment) Set(name string, val Object) Object {
 	e.store[name] = val
 	return val
 }
 
+// Assign updates an existing binding in the closest environment that defines it. It returns
+// false without setting anything if the name isn't defined.
+func (e *Environment) Assign(name string, val Object) (Object, bool) {
+	if _, ok := e.store[name]; ok {
+		e.store[name] = val
+		return val, true
+	}
+	if e.outer != nil {
+		return e.outer.Assign(name, val)
+	}
+	return nil, false
+}
+
 func (e *Environment) FullList() map[string]string {
 	list := make(map[string]string)
 	for name, v := range e.store {
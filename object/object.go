@@ -4,9 +4,12 @@ import (
 	"bytes"
 	"fmt"
 	"hash/fnv"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/jumballaya/servo/ast"
+	"github.com/jumballaya/servo/token"
 )
 
 type BuiltinFunction func(args ...Object) Object
@@ -18,11 +21,16 @@ const (
 	FLOAT_OBJ        = "FLOAT"
 	BOOLEAN_OBJ      = "BOOLEAN"
 	STRING_OBJ       = "STRING"
+	BYTES_OBJ        = "BYTES"
+	REGEX_OBJ        = "REGEX"
 	ARRAY_OBJ        = "ARRAY"
 	HASH_OBJ         = "HASH"
 	NULL_OBJ         = "NULL"
 	RETURN_VALUE_OBJ = "RETURN_VALUE"
+	BREAK_OBJ        = "BREAK"
+	CONTINUE_OBJ     = "CONTINUE"
 	ERROR_OBJ        = "ERROR"
+	ERROR_VALUE_OBJ  = "ERROR_VALUE"
 	FUNCTION_OBJ     = "FUNCTION"
 	BUILTIN_OBJ      = "BUILTIN"
 	CLASS_OBJ        = "CLASS"
@@ -81,15 +89,53 @@ type ReturnValue struct {
 func (rv *ReturnValue) Type() ObjectType { return RETURN_VALUE_OBJ }
 func (rv *ReturnValue) Inspect() string  { return rv.Value.Inspect() }
 
+// Break and Continue unwind block statements like ReturnValue until they reach the enclosing loop
+type Break struct{}
+
+func (b *Break) Type() ObjectType { return BREAK_OBJ }
+func (b *Break) Inspect() string  { return "break" }
+
+type Continue struct{}
+
+func (c *Continue) Type() ObjectType { return CONTINUE_OBJ }
+func (c *Continue) Inspect() string  { return "continue" }
+
+// Error unwinds the evaluation until it is caught by a try statement or reaches the top of
+// the program. Kind is empty for the interpreter's own errors.
 type Error struct {
 	Message string
+	Kind    string
+	Pos     token.Position
 }
 
 func (e *Error) Type() ObjectType { return ERROR_OBJ }
-func (e *Error) Inspect() string  { return "Error: " + e.Message }
+func (e *Error) Inspect() string  { return inspectError(e.Kind, e.Message, e.Pos) }
+
+// ErrorValue is an error that has been caught, or made with the Error builtin. Unlike Error it
+// is a normal value that can be passed around, inspected and thrown again.
+type ErrorValue struct {
+	Message string
+	Kind    string
+	Pos     token.Position
+}
+
+func (ev *ErrorValue) Type() ObjectType { return ERROR_VALUE_OBJ }
+func (ev *ErrorValue) Inspect() string  { return inspectError(ev.Kind, ev.Message, ev.Pos) }
+
+func inspectError(kind, message string, pos token.Position) string {
+	if kind == "" {
+		kind = "Error"
+	}
+	if pos.IsValid() {
+		return kind + ": " + pos.String() + ": " + message
+	}
+	return kind + ": " + message
+}
 
 type Function struct {
-	Parameters []*ast.Identifier
+	Name       string
+	Parameters []ast.Pattern
+	Rest       *ast.Identifier
 	Body       *ast.BlockStatement
 	Env        *Environment
 }
@@ -104,6 +150,9 @@ func (f *Function) Inspect() string {
 	for _, p := range f.Parameters {
 		params = append(params, p.String())
 	}
+	if f.Rest != nil {
+		params = append(params, "..."+f.Rest.String())
+	}
 
 	out.WriteString("fn")
 	out.WriteString("(")
@@ -127,6 +176,28 @@ func (s *String) HashKey() HashKey {
 	return HashKey{Type: s.Type(), Value: h.Sum64()}
 }
 
+type Bytes struct {
+	Value []byte
+}
+
+func (b *Bytes) Type() ObjectType { return BYTES_OBJ }
+func (b *Bytes) Inspect() string  { return "b" + strconv.Quote(string(b.Value)) }
+func (b *Bytes) HashKey() HashKey {
+	h := fnv.New64a()
+	h.Write(b.Value)
+
+	return HashKey{Type: b.Type(), Value: h.Sum64()}
+}
+
+type Regex struct {
+	Pattern string
+	Flags   string
+	Value   *regexp.Regexp
+}
+
+func (r *Regex) Type() ObjectType { return REGEX_OBJ }
+func (r *Regex) Inspect() string  { return "/" + r.Pattern + "/" + r.Flags }
+
 type Builtin struct {
 	Fn BuiltinFunction
 }
@@ -58,3 +58,17 @@ func TestIntegerHashKey(t *testing.T) {
 		t.Errorf("integers with twoerent content have same hash keys")
 	}
 }
+
+func TestBytesHashKey(t *testing.T) {
+	hello1 := &Bytes{Value: []byte("Hello World")}
+	hello2 := &Bytes{Value: []byte("Hello World")}
+	helloStr := &String{Value: "Hello World"}
+
+	if hello1.HashKey() != hello2.HashKey() {
+		t.Errorf("bytes with same content have different hash keys")
+	}
+
+	if hello1.HashKey() == helloStr.HashKey() {
+		t.Errorf("bytes have the same hash key as a string with the same content")
+	}
+}
@@ -21,7 +21,7 @@ func checkMethodMiddleware(method string) RouteMiddleware {
 	fn := func(w http.ResponseWriter, r *http.Request, rm RouteMethod) {
 		if r.Method != method {
 			msg := fmt.Sprintf("Path %s has no method %s", r.URL.Path, r.Method)
-			fmt.Fprintf(w, msg)
+			fmt.Fprint(w, msg)
 		} else {
 			rm.ServeHTTP(w, r)
 		}
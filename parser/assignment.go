@@ -8,18 +8,37 @@ import (
 )
 
 // Parse Statement checks the statement type and runs the corresponding parsing function
-// Currently only 3 types of statements exist:
-//		1. Let statements
-//    2. Return statements
-//    3. Expression statements (everything else)
+// The statement types are:
+//  1. Let and class statements
+//  2. Return statements
+//  3. Loops: for, while, break and continue
+//  4. Error handling: try/catch/finally and throw
+//  5. Expression statements (everything else)
 func (p *Parser) parseStatement() ast.Statement {
 	switch p.curToken.Type {
 	case token.LET:
 		return p.parseLetStatement()
 	case token.RETURN:
 		return p.parseReturnStatement()
+	case token.FUNCTION:
+		if p.peekTokenIs(token.IDENT) {
+			return p.parseFunctionStatement()
+		}
+		return p.parseExpressionStatement()
 	case token.CLASS:
 		return p.parseClassStatement()
+	case token.FOR:
+		return p.parseForStatement()
+	case token.WHILE:
+		return p.parseWhileStatement()
+	case token.BREAK, token.CONTINUE:
+		return p.parseLoopControlStatement()
+	case token.TRY:
+		return p.parseTryStatement()
+	case token.THROW:
+		return p.parseThrowStatement()
+	case token.EXPORT:
+		return p.parseExportStatement()
 	default:
 		return p.parseExpressionStatement()
 	}
@@ -28,16 +47,35 @@ func (p *Parser) parseStatement() ast.Statement {
 // Parse Let Statement attempts to assign a right-hand expression to a left-hand
 // identifier using the assignment operator, '='
 func (p *Parser) parseLetStatement() *ast.LetStatement {
-	stmt := &ast.LetStatement{Token: p.curToken}
-	if !p.expectPeek(token.IDENT) {
-		return nil
+	stmt := &ast.LetStatement{Token: p.curToken, Doc: p.curDoc}
+
+	// Destructuring, e.g. `let [a, b] = pair` or `let {name} = user`
+	if p.peekTokenIs(token.LBRACKET) || p.peekTokenIs(token.LBRACE) {
+		p.nextToken()
+		stmt.Pattern = p.parsePattern()
+		if stmt.Pattern == nil {
+			return nil
+		}
+	} else {
+		if !p.expectPeek(token.IDENT) {
+			return nil
+		}
+		stmt.Name = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
 	}
-	stmt.Name = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+
 	if !p.expectPeek(token.ASSIGN) {
 		return nil
 	}
 	p.nextToken()
 	stmt.Value = p.parseExpression(LOWEST)
+	if fn, ok := stmt.Value.(*ast.FunctionLiteral); ok {
+		if fn.Doc == "" {
+			fn.Doc = stmt.Doc
+		}
+		if fn.Name == "" && stmt.Name != nil {
+			fn.Name = stmt.Name.Value
+		}
+	}
 	if p.peekTokenIs(token.SEMICOLON) {
 		p.nextToken()
 	}
@@ -55,65 +93,165 @@ func (p *Parser) parseReturnStatement() *ast.ReturnStatement {
 	return stmt
 }
 
-// Parse Import Statement attempts to build the import statement
+// Parse Import Statement attempts to build the import statement in any of its forms:
+// `import name from './x.svo'`, `import {a, b as c} from './x.svo'` or `import './x.svo' as x`
 func (p *Parser) parseImportStatement() ast.Expression {
 	// token.IMPORT with value 'import'
 	stmt := &ast.ImportExpression{Token: p.curToken, Value: p.curToken.Literal}
 
-	// Check for the identifier e.g. map in `import map from './test.svo';`
-	if !p.expectPeek(token.IDENT) {
+	switch {
+	case p.peekTokenIs(token.STRING):
+		// Namespace import, the path comes first
+		p.nextToken()
+		if stmt.Path = p.parseImportPath(); stmt.Path == nil {
+			return nil
+		}
+		if !p.expectPeek(token.AS) || !p.expectPeek(token.IDENT) {
+			return nil
+		}
+		stmt.Namespace = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+		return stmt
+
+	case p.peekTokenIs(token.LBRACE):
+		p.nextToken()
+		if stmt.Specifiers = p.parseImportSpecifiers(); stmt.Specifiers == nil {
+			return nil
+		}
+
+	default:
+		// Check for the identifier e.g. map in `import map from './test.svo';`
+		if !p.expectPeek(token.IDENT) {
+			return nil
+		}
+		stmt.Name = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	}
+
+	// Make sure the next token is a FROM token followed by the path
+	if !p.expectPeek(token.FROM) || !p.expectPeek(token.STRING) {
+		return nil
+	}
+	if stmt.Path = p.parseImportPath(); stmt.Path == nil {
 		return nil
 	}
 
-	// Set the name of the import
-	stmt.Name = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	return stmt
+}
 
-	// Make sure the next token is a FROM token
-	if !p.expectPeek(token.FROM) {
-		return nil
+// Parse Import Specifiers parses the names in `{a, b as c}`, a trailing comma is allowed
+func (p *Parser) parseImportSpecifiers() []*ast.ImportSpecifier {
+	specifiers := []*ast.ImportSpecifier{}
+
+	for !p.peekTokenIs(token.RBRACE) {
+		if !p.expectPeek(token.IDENT) {
+			return nil
+		}
+		spec := &ast.ImportSpecifier{Name: &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}}
+
+		if p.peekTokenIs(token.AS) {
+			p.nextToken()
+			if !p.expectPeek(token.IDENT) {
+				return nil
+			}
+			spec.Alias = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+		}
+		specifiers = append(specifiers, spec)
+
+		if !p.peekTokenIs(token.RBRACE) && !p.expectPeek(token.COMMA) {
+			return nil
+		}
 	}
+	p.nextToken()
 
-	// Make sure the next token is a string
-	if !p.expectPeek(token.STRING) {
+	if len(specifiers) == 0 {
+		p.addError(p.curToken.Pos, "expected at least one name to import")
 		return nil
 	}
 
-	// Make sure the string isn't empty
+	return specifiers
+}
+
+// Parse Import Path builds the path of an import from the current string token
+func (p *Parser) parseImportPath() *ast.StringLiteral {
 	if p.curToken.Literal == "" {
+		p.addError(p.curToken.Pos, "import path cannot be empty")
 		return nil
 	}
+	return &ast.StringLiteral{Token: p.curToken, Value: p.curToken.Literal}
+}
 
-	// Set the path from the string
-	stmt.Path = &ast.StringLiteral{Token: p.curToken, Value: p.curToken.Literal}
+// Parse Export Statement builds an export of a top-level let statement, class declaration or
+// function statement, e.g. `export let x = 1`
+func (p *Parser) parseExportStatement() ast.Statement {
+	stmt := &ast.ExportStatement{Token: p.curToken}
 
-	// Check for SEMICOLON
-	if p.peekTokenIs(token.SEMICOLON) {
-		p.nextToken()
+	// Exports inside of a block can't be imported by anything, but the declaration is still fine
+	if p.depth > 0 {
+		p.reportError(&ParseError{Pos: stmt.Token.Pos, Message: "export is only allowed at the top level of a module"})
 	}
 
+	// The doc comment before `export` belongs to the declaration
+	doc := p.curDoc
+	p.nextToken()
+	p.curDoc = doc
+
+	switch {
+	case p.curTokenIs(token.LET):
+		if let := p.parseLetStatement(); let != nil {
+			stmt.Statement = let
+		}
+	case p.curTokenIs(token.CLASS):
+		stmt.Statement = p.parseClassStatement()
+	case p.curTokenIs(token.FUNCTION) && p.peekTokenIs(token.IDENT):
+		if fn := p.parseFunctionStatement(); fn != nil {
+			stmt.Statement = fn
+		}
+	default:
+		msg := fmt.Sprintf("only let, class and fn declarations can be exported, got %s", p.curToken.Type)
+		p.addError(p.curToken.Pos, msg)
+	}
+
+	if stmt.Statement == nil {
+		return nil
+	}
 	return stmt
 }
 
-// Parse Reassign takes a reassignment operator like `[identifier] += value` and turns it into
-// a brand new assignment expression
+// Compound Operators maps each compound assignment operator to the infix operator it applies
+var compoundOperators = map[token.TokenType]string{
+	token.PLUSASSIGN:       token.PLUS,
+	token.MINUSASSIGN:      token.MINUS,
+	token.ASTERISKASSIGN:   token.ASTERISK,
+	token.SLASHASSIGN:      token.SLASH,
+	token.MODULOASSIGN:     token.MODULO,
+	token.CARROTASSIGN:     token.CARROT,
+	token.BITWISEORASSIGN:  token.BITWISEOR,
+	token.BITWISEANDASSIGN: token.BITWISEAND,
+	token.SHIFTLEFTASSIGN:  token.SHIFTLEFT,
+	token.SHIFTRIGHTASSIGN: token.SHIFTRIGHT,
+}
+
+// Parse Reassign takes an assignment like `[target] = value` or `[target] += value` and turns
+// it into an assignment expression. The target can be an identifier, an index or an attribute.
 func (p *Parser) parseReassignExpression(left ast.Expression) ast.Expression {
-	stmt := &ast.AssignExpression{Token: p.curToken, Left: left}
-	p.nextToken()
-	right := p.parseExpression(LOWEST).(ast.Expression)
+	stmt := &ast.AssignExpression{Token: p.curToken, Left: left, Operator: compoundOperators[p.curToken.Type]}
+
+	// A left side that failed to parse was already reported and may be missing pieces
+	if p.panicking {
+		return nil
+	}
 
-	switch stmt.Token.Type {
-	case token.PLUSASSIGN:
-		stmt.Value = makeInfix(token.PLUS, left, right)
-	case token.MINUSASSIGN:
-		stmt.Value = makeInfix(token.MINUS, left, right)
-	case token.ASTERISKASSIGN:
-		stmt.Value = makeInfix(token.ASTERISK, left, right)
-	case token.SLASHASSIGN:
-		stmt.Value = makeInfix(token.SLASH, left, right)
-	case token.ASSIGN:
-		stmt.Value = p.parseExpression(LOWEST).(ast.Expression)
+	switch left.(type) {
+	case *ast.Identifier, *ast.IndexExpression, *ast.AttributeExpression:
+	case nil:
+		return nil
+	default:
+		p.addError(stmt.Token.Pos, fmt.Sprintf("cannot assign to %s", left.String()))
+		return nil
 	}
 
+	p.nextToken()
+	stmt.Value = p.parseExpression(LOWEST)
+
 	if p.peekTokenIs(token.SEMICOLON) {
 		p.nextToken()
 	}
@@ -121,26 +259,17 @@ func (p *Parser) parseReassignExpression(left ast.Expression) ast.Expression {
 	return stmt
 }
 
-// Make Infix creates an infix expression
-func makeInfix(t token.TokenType, left, right ast.Expression) *ast.InfixExpression {
-	return &ast.InfixExpression{
-		Token:    token.Token{Type: t, Literal: string(t)},
-		Left:     left,
-		Operator: string(t),
-		Right:    right,
-	}
-}
-
 // Parse Class Statement
 func (p *Parser) parseClassStatement() ast.Statement {
 	classToken := p.curToken
+	doc := p.curDoc
 	if !p.expectPeek(token.IDENT) {
 		msg := fmt.Sprintf("could not parse %q as an identifier", p.peekToken.Literal)
-		p.errors = append(p.errors, msg)
+		p.addError(p.peekToken.Pos, msg)
 		return nil
 	}
 
-	stmt := &ast.LetStatement{Token: createKeywordToken("let")}
+	stmt := &ast.LetStatement{Token: createKeywordToken("let", classToken.Pos), Doc: doc}
 	stmt.Name = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
 
 	p.insertToken(classToken)
@@ -154,6 +283,7 @@ func (p *Parser) parseClassStatement() ast.Statement {
 
 	if class, ok := stmt.Value.(*ast.ClassLiteral); ok {
 		class.Name = stmt.Name.String()
+		class.Doc = doc
 	}
 
 	if p.peekTokenIs(token.SEMICOLON) {
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/jumballaya/servo/ast"
@@ -80,26 +81,126 @@ func TestReturnStatements(t *testing.T) {
 }
 
 func TestParsingImports(t *testing.T) {
-	tests := []string{
-		"import map from 'Array';",
-		"import asd from 'Array';",
-		"import nums from './.demos/arrays.svo';",
-		"import asd from './asdasd.svo';",
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"import map from 'Array';", "import map from 'Array';"},
+		{"import asd from 'Array';", "import asd from 'Array';"},
+		{"import nums from './.demos/arrays.svo';", "import nums from './.demos/arrays.svo';"},
+		{"import asd from './asdasd.svo';", "import asd from './asdasd.svo';"},
+		{"import nums from './nums.svo';\n[nums, 1]", "import nums from './nums.svo';"},
+		{"import './db.svo' as db;", "import './db.svo' as db;"},
+		{"import {a} from './x.svo'", "import {a} from './x.svo';"},
+		{"import {a, b as c,} from './x.svo'", "import {a, b as c} from './x.svo';"},
 	}
 
-	for _, input := range tests {
-		l := lexer.New(input)
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
 		p := New(l)
 		program := p.ParseProgram()
 		checkParserErrors(t, p)
 		stmt := program.Statements[0].(*ast.ExpressionStatement)
 
-		_, ok := stmt.Expression.(*ast.ImportExpression)
+		imp, ok := stmt.Expression.(*ast.ImportExpression)
 		if !ok {
 			t.Fatalf("exp is not ast.ImportExpression. got=%T", stmt.Expression)
 		}
+		if imp.String() != tt.expected {
+			t.Errorf("import is wrong. expected=%q, got=%q", tt.expected, imp.String())
+		}
+		if len(program.Statements) > 1 {
+			if _, ok := program.Statements[1].(*ast.ExpressionStatement).Expression.(*ast.ArrayLiteral); !ok {
+				t.Fatalf("statement after import is not an array. got=%s", program.Statements[1])
+			}
+		}
+	}
+}
+
+func TestInvalidImports(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"import {} from './x.svo'", "1:9: expected at least one name to import"},
+		{"import {a b} from './x.svo'", "1:11: expected next token to be ,, got IDENT instead"},
+		{"import {a as} from './x.svo'", "1:13: expected next token to be IDENT, got } instead"},
+		{"import './x.svo' from x", "1:18: expected next token to be AS, got FROM instead"},
+		{"import a from ''", "1:15: import path cannot be empty"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) != 1 {
+			t.Fatalf("wrong number of errors for %q. got=%d (%v)", tt.input, len(errors), errors)
+		}
+		if msg := strings.SplitN(errors[0].Error(), "\n", 2)[0]; msg != tt.expected {
+			t.Errorf("wrong error. expected=%q, got=%q", tt.expected, msg)
+		}
+	}
+}
+
+func TestExportStatement(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		names    []string
+	}{
+		{"export let x = 5;", "export let x = 5;", []string{"x"}},
+		{"export let [a, {b, c: d}, ...rest] = x;", "export let [a, {b, c: d}, ...rest] = x;", []string{"a", "b", "d", "rest"}},
+		{"export fn add(a, b) { a + b }", "export fn add(a, b) (a + b)", []string{"add"}},
+		{"export class Point { let x = 0; }", "export let Point = ", []string{"Point"}},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("program.Statements does not contain 1 statement. got=%d", len(program.Statements))
+		}
+		stmt, ok := program.Statements[0].(*ast.ExportStatement)
+		if !ok {
+			t.Fatalf("stmt is not ast.ExportStatement. got=%T", program.Statements[0])
+		}
+		if !strings.HasPrefix(stmt.String(), tt.expected) {
+			t.Errorf("export is wrong. expected=%q, got=%q", tt.expected, stmt.String())
+		}
+		if strings.Join(stmt.Names(), ",") != strings.Join(tt.names, ",") {
+			t.Errorf("wrong exported names. expected=%v, got=%v", tt.names, stmt.Names())
+		}
+	}
+}
+
+func TestInvalidExportStatement(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"export 5;", "1:8: only let, class and fn declarations can be exported, got INT"},
+		{"export fn(x) { x }", "1:8: only let, class and fn declarations can be exported, got FUNCTION"},
+		{"fn f() { export let x = 1; x }", "1:10: export is only allowed at the top level of a module"},
 	}
 
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) != 1 {
+			t.Fatalf("wrong number of errors for %q. got=%d (%v)", tt.input, len(errors), errors)
+		}
+		if msg := strings.SplitN(errors[0].Error(), "\n", 2)[0]; msg != tt.expected {
+			t.Errorf("wrong error. expected=%q, got=%q", tt.expected, msg)
+		}
+	}
 }
 
 func TestReassignExpression(t *testing.T) {
@@ -138,3 +239,63 @@ func TestReassignExpression(t *testing.T) {
 		}
 	}
 }
+
+func TestAssignTargets(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"x = 1", "x = 1;"},
+		{"x %= 2", "x %= 2;"},
+		{"arr[0] = 1", "(arr[0]) = 1;"},
+		{"h[\"k\"] += 2", "(h[k]) += 2;"},
+		{"other.field = 3", "(other.field) = 3;"},
+		{"a.b[1].c <<= 4", "(((a.b)[1]).c) <<= 4;"},
+		{"x = y = 5", "x = y = 5;"},
+		{"x = y += z = 5", "x = y += z = 5;"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		if _, ok := stmt.Expression.(*ast.AssignExpression); !ok {
+			t.Fatalf("exp not *ast.AssignExpression. got=%T", stmt.Expression)
+		}
+
+		if stmt.Expression.String() != tt.expected {
+			t.Errorf("wrong assignment. expected=%q, got=%q", tt.expected, stmt.Expression.String())
+		}
+	}
+}
+
+func TestInvalidAssignTarget(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"f() = 1", "1:5: cannot assign to f()"},
+		// Left sides that didn't parse only report their own error
+		{"f(-) = 1", "1:4: no prefix parse function for ) found"},
+		{"-) = 1", "1:2: no prefix parse function for ) found"},
+		{"[...] = 1", "1:5: no prefix parse function for ] found"},
+		{"f(...) = 1", "1:6: no prefix parse function for ) found"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) != 1 {
+			t.Fatalf("wrong number of errors for %q. got=%d (%v)", tt.input, len(errors), errors)
+		}
+		if msg := strings.SplitN(errors[0].Error(), "\n", 2)[0]; msg != tt.expected {
+			t.Errorf("wrong error message for %q. expected=%q, got=%q", tt.input, tt.expected, msg)
+		}
+	}
+}
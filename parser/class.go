@@ -1,6 +1,8 @@
 package parser
 
 import (
+	"fmt"
+
 	"github.com/jumballaya/servo/ast"
 	"github.com/jumballaya/servo/token"
 )
@@ -8,6 +10,8 @@ import (
 // Parse Class Literal
 func (p *Parser) parseClassLiteral() ast.Expression {
 	c := &ast.ClassLiteral{
+		Token:   p.curToken,
+		Doc:     p.curDoc,
 		Fields:  make([]*ast.LetStatement, 0),
 		Methods: make(map[string]*ast.FunctionLiteral),
 	}
@@ -26,10 +30,18 @@ func (p *Parser) parseClassLiteral() ast.Expression {
 
 	body := p.parseBlockStatement()
 
+	// The body parsed fine, so every misplaced statement is reported without stopping
 	for _, stmt := range body.Statements {
 		let, ok := stmt.(*ast.LetStatement)
 		if !ok {
-			return nil
+			msg := fmt.Sprintf("a class body can only contain let statements, got %q", stmt.TokenLiteral())
+			p.reportError(&ParseError{Pos: stmt.Pos(), Expected: token.LET, Message: msg})
+			continue
+		}
+
+		if let.Pattern != nil {
+			p.reportError(&ParseError{Pos: let.Pos(), Message: "class fields cannot be destructured"})
+			continue
 		}
 
 		switch s := let.Value.(type) {
@@ -50,7 +62,7 @@ func (p *Parser) parseClassLiteral() ast.Expression {
 
 // Parse New Expression
 func (p *Parser) parseNewExpression() ast.Expression {
-	i := &ast.InstanceLiteral{}
+	i := &ast.InstanceLiteral{Token: p.curToken}
 
 	p.nextToken()
 	classExp := p.parseExpression(LOWEST)
@@ -58,7 +70,7 @@ func (p *Parser) parseNewExpression() ast.Expression {
 	call, ok := classExp.(*ast.CallExpression)
 	if !ok {
 		msg := "invalid class instance creation"
-		p.errors = append(p.errors, msg)
+		p.addError(i.Token.Pos, msg)
 		return nil
 	}
 
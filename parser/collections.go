@@ -1,8 +1,6 @@
 package parser
 
 import (
-	"fmt"
-
 	"github.com/jumballaya/servo/ast"
 	"github.com/jumballaya/servo/token"
 )
@@ -25,8 +23,8 @@ func (p *Parser) parseHashLiteral() ast.Expression {
 		key := p.parseExpression(LOWEST)
 
 		if !p.expectPeek(token.COLON) {
-			msg := fmt.Sprintf("hash declaration missing ':'")
-			p.errors = append(p.errors, msg)
+			msg := "hash declaration missing ':'"
+			p.addError(p.peekToken.Pos, msg)
 			return nil
 		}
 
@@ -35,16 +33,16 @@ func (p *Parser) parseHashLiteral() ast.Expression {
 		hash.Pairs[key] = value
 
 		if !p.peekTokenIs(token.RBRACE) && !p.expectPeek(token.COMMA) {
-			msg := fmt.Sprintf("hash declaration must include ',' or '}'")
-			p.errors = append(p.errors, msg)
+			msg := "hash declaration must include ',' or '}'"
+			p.addError(p.peekToken.Pos, msg)
 			return nil
 		}
 
 	}
 
 	if !p.expectPeek(token.RBRACE) {
-		msg := fmt.Sprintf("hash declaration must end with '}'")
-		p.errors = append(p.errors, msg)
+		msg := "hash declaration must end with '}'"
+		p.addError(p.peekToken.Pos, msg)
 		return nil
 	}
 
@@ -56,12 +54,54 @@ func (p *Parser) parseHashLiteral() ast.Expression {
 func (p *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
 	exp := &ast.IndexExpression{Token: p.curToken, Left: left}
 
+	// Slice without a start, e.g. `arr[:2]` or `arr[::2]`
+	if p.peekTokenIs(token.COLON) || p.peekTokenIs(token.COLONCOLON) {
+		return p.parseSliceExpression(&ast.SliceExpression{Token: exp.Token, Left: left})
+	}
+
 	p.nextToken()
 	exp.Index = p.parseExpression(LOWEST)
 
+	if p.peekTokenIs(token.COLON) || p.peekTokenIs(token.COLONCOLON) {
+		return p.parseSliceExpression(&ast.SliceExpression{Token: exp.Token, Left: left, Start: exp.Index})
+	}
+
+	if !p.expectPeek(token.RBRACKET) {
+		msg := "index expressions must end with ']'"
+		p.addError(p.peekToken.Pos, msg)
+		return nil
+	}
+
+	return exp
+}
+
+// Parse Slice Expression parses the end and step of a slice, starting at the first colon.
+// `::` comes from the lexer as a single token when the end is left out.
+func (p *Parser) parseSliceExpression(exp *ast.SliceExpression) ast.Expression {
+	p.nextToken()
+
+	// Only a second colon can be followed by a step
+	stepColon := p.curTokenIs(token.COLONCOLON)
+
+	if p.curTokenIs(token.COLON) {
+		if !p.peekTokenIs(token.COLON) && !p.peekTokenIs(token.RBRACKET) {
+			p.nextToken()
+			exp.End = p.parseExpression(LOWEST)
+		}
+		if p.peekTokenIs(token.COLON) {
+			p.nextToken()
+			stepColon = true
+		}
+	}
+
+	if stepColon && !p.peekTokenIs(token.RBRACKET) {
+		p.nextToken()
+		exp.Step = p.parseExpression(LOWEST)
+	}
+
 	if !p.expectPeek(token.RBRACKET) {
-		msg := fmt.Sprintf("index expressions must end with ']'")
-		p.errors = append(p.errors, msg)
+		msg := "slice expressions must end with ']'"
+		p.addError(p.peekToken.Pos, msg)
 		return nil
 	}
 
@@ -70,18 +110,23 @@ func (p *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
 
 // Parse Attribute Expression
 func (p *Parser) parseAttributeExpression(left ast.Expression) ast.Expression {
-	exp := &ast.AttributeExpression{Token: p.curToken, Left: left}
+	exp := &ast.AttributeExpression{Token: p.curToken, Left: left, Optional: p.curTokenIs(token.QDOT)}
 	p.nextToken()
+
+	// Keywords are fine as attribute names, e.g. `re.match(s)`
+	if !p.curTokenIs(token.IDENT) && token.LookupIdent(p.curToken.Literal) == p.curToken.Type {
+		p.curToken.Type = token.IDENT
+	}
 	i := p.parseExpression(ASSIGN)
 
 	ident, ok := i.(*ast.Identifier)
 	if !ok {
-		msg := fmt.Sprintf("attribute operator requires an identifier")
-		p.errors = append(p.errors, msg)
+		msg := "attribute operator requires an identifier"
+		p.addError(exp.Token.Pos, msg)
 		return nil
 	}
 
-	t := token.Token{Type: token.STRING, Literal: ident.Value}
+	t := token.Token{Type: token.STRING, Literal: ident.Value, Pos: ident.Token.Pos}
 	exp.Index = &ast.StringLiteral{Token: t, Value: ident.Value}
 
 	return exp
@@ -72,6 +72,39 @@ func TestParsingIndexExpressions(t *testing.T) {
 	}
 }
 
+func TestParsingSliceExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"a[1:3]", "(a[1:3])"},
+		{"a[:2]", "(a[:2])"},
+		{"a[2:]", "(a[2:])"},
+		{"a[:]", "(a[:])"},
+		{"a[::2]", "(a[::2])"},
+		{"a[1::2]", "(a[1::2])"},
+		{"a[1:5:2]", "(a[1:5:2])"},
+		{"a[:5:]", "(a[:5])"},
+		{"a[-3:len(a) - 1]", "(a[(-3):(len(a) - 1)])"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		if _, ok := stmt.Expression.(*ast.SliceExpression); !ok {
+			t.Fatalf("exp not *ast.SliceExpression. got=%T", stmt.Expression)
+		}
+
+		if stmt.Expression.String() != tt.expected {
+			t.Errorf("wrong slice. expected=%q, got=%q", tt.expected, stmt.Expression.String())
+		}
+	}
+}
+
 func TestParsingEmptyHashLiteral(t *testing.T) {
 	input := "{}"
 
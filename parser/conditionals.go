@@ -5,6 +5,24 @@ import (
 	"github.com/jumballaya/servo/token"
 )
 
+// Parse Ternary Expression parses `cond ? a : b`. It is right associative, so
+// `a ? b : c ? d : e` is `a ? b : (c ? d : e)`
+func (p *Parser) parseTernaryExpression(condition ast.Expression) ast.Expression {
+	exp := &ast.TernaryExpression{Token: p.curToken, Condition: condition}
+
+	p.nextToken()
+	exp.Consequence = p.parseExpression(LOWEST)
+
+	if !p.expectPeek(token.COLON) {
+		return nil
+	}
+
+	p.nextToken()
+	exp.Alternative = p.parseExpression(LOWEST)
+
+	return exp
+}
+
 // Parse If Expression builds the different branches of the if/else expression
 func (p *Parser) parseIfExpression() ast.Expression {
 	expression := &ast.IfExpression{Token: p.curToken}
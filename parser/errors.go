@@ -0,0 +1,99 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/jumballaya/servo/token"
+)
+
+// ParseError is a syntax error found while parsing. Expected and Got are set when the parser
+// wanted a specific token, e.g. Expected `)` but Got `IDENT`.
+type ParseError struct {
+	Pos      token.Position
+	Expected token.TokenType
+	Got      token.TokenType
+	Message  string
+	Snippet  string // the offending source line with a caret under the error
+}
+
+// Error formats the error as `file:line:col: message` followed by the source snippet
+func (e *ParseError) Error() string {
+	msg := fmt.Sprintf("%s: %s", e.Pos, e.Message)
+	if e.Snippet != "" {
+		msg += "\n" + e.Snippet
+	}
+	return msg
+}
+
+// Tokens that start a statement, error recovery picks parsing back up right before them
+var statementStarts = map[token.TokenType]bool{
+	token.LET:      true,
+	token.RETURN:   true,
+	token.CLASS:    true,
+	token.FUNCTION: true,
+	token.IF:       true,
+	token.FOR:      true,
+	token.WHILE:    true,
+	token.BREAK:    true,
+	token.CONTINUE: true,
+	token.TRY:      true,
+	token.THROW:    true,
+	token.IMPORT:   true,
+	token.EXPORT:   true,
+}
+
+// Peek Error adds an error stating that the current token is not the given token
+func (p *Parser) peekError(t token.TokenType) {
+	msg := fmt.Sprintf("expected next token to be %s, got %s instead", t, p.peekToken.Type)
+	p.addParseError(&ParseError{Pos: p.peekToken.Pos, Expected: t, Got: p.peekToken.Type, Message: msg})
+}
+
+// No Prefix Parse Function Error adds an error if there is no prefix parse
+// function for a given token type
+func (p *Parser) noPrefixParseFnError(t token.TokenType) {
+	msg := fmt.Sprintf("no prefix parse function for %s found", t)
+	p.addParseError(&ParseError{Pos: p.curToken.Pos, Got: t, Message: msg})
+}
+
+// Add Error records a syntax error at pos and puts the parser in panic mode
+func (p *Parser) addError(pos token.Position, msg string) {
+	p.addParseError(&ParseError{Pos: pos, Message: msg})
+}
+
+// Add Parse Error records the error and puts the parser in panic mode. Until the parser
+// synchronizes at the next statement, any other error is a consequence of this one and is
+// dropped.
+func (p *Parser) addParseError(err *ParseError) {
+	if p.panicking {
+		return
+	}
+	p.reportError(err)
+	p.panicking = true
+}
+
+// Report Error records an error without going into panic mode, for errors that leave the
+// parser in a good state like a misplaced statement in a class body
+func (p *Parser) reportError(err *ParseError) {
+	err.Snippet = err.Pos.Snippet(p.l.Input())
+	p.errors = append(p.errors, err)
+}
+
+// Synchronize skips the rest of a statement that had an error so parsing can pick back up at
+// the next one. It stops on a `;` or right before a statement keyword or the `}` closing the
+// block at depth, skipping over nested braces. When the error already consumed that `}` the
+// current token is left on it.
+func (p *Parser) synchronize(depth int) {
+	defer func() { p.panicking = false }()
+
+	for !p.curTokenIs(token.EOF) && p.depth >= depth {
+		if p.depth == depth {
+			if p.curTokenIs(token.SEMICOLON) || p.peekTokenIs(token.RBRACE) || p.peekTokenIs(token.EOF) {
+				return
+			}
+			if statementStarts[p.peekToken.Type] {
+				return
+			}
+		}
+		p.nextToken()
+	}
+}
@@ -0,0 +1,122 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/jumballaya/servo/lexer"
+	"github.com/jumballaya/servo/token"
+)
+
+func TestParserErrorRecovery(t *testing.T) {
+	tests := []struct {
+		input    string
+		errors   []string
+		expected string
+	}{
+		{
+			"let = 5;\nlet y = ;\nlet z = 3;\nz + ;",
+			[]string{
+				"1:5: expected next token to be IDENT, got = instead",
+				"2:9: no prefix parse function for ; found",
+				"4:5: no prefix parse function for ; found",
+			},
+			"let z = 3;",
+		},
+		{
+			"let f = fn(x) {\n  let a = ;\n  x +\n};\nlet ok = 1;\nlet b = (1 + ;",
+			[]string{
+				"2:11: no prefix parse function for ; found",
+				"4:1: no prefix parse function for } found",
+				"6:14: no prefix parse function for ; found",
+			},
+			"let f = fn(x) ;let ok = 1;",
+		},
+		{
+			"if (x { 1 } let y = 2; let = 3",
+			[]string{
+				"1:7: expected next token to be ), got { instead",
+				"1:28: expected next token to be IDENT, got = instead",
+			},
+			"let y = 2;",
+		},
+		{
+			"let x = {a: }; let y = [1, 2; let z = 1 };",
+			[]string{
+				"1:13: no prefix parse function for } found",
+				"1:29: expected next token to be ], got ; instead",
+				"1:41: no prefix parse function for } found",
+			},
+			"let z = 1;",
+		},
+		{
+			"match (x) { 1 => a 2 => b }; let ok = 1",
+			[]string{"1:20: expected next token to be ,, got INT instead"},
+			"let ok = 1;",
+		},
+		{
+			"class A {\n  let a = 1;\n  5;\n  let [b] = c;\n}\nlet q = ;",
+			[]string{
+				`3:3: a class body can only contain let statements, got "5"`,
+				"4:3: class fields cannot be destructured",
+				"6:9: no prefix parse function for ; found",
+			},
+			"let A = class A:: {...};",
+		},
+		{
+			"let s = \"abc",
+			[]string{"1:9: unterminated string literal"},
+			"",
+		},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) != len(tt.errors) {
+			t.Errorf("%q: wrong number of errors. expected=%d, got=%d", tt.input, len(tt.errors), len(errors))
+			for _, err := range errors {
+				t.Errorf("parser error: %q", err.Error())
+			}
+			continue
+		}
+
+		for i, err := range errors {
+			msg := err.Pos.String() + ": " + err.Message
+			if msg != tt.errors[i] {
+				t.Errorf("wrong error message. expected=%q, got=%q", tt.errors[i], msg)
+			}
+		}
+
+		if program.String() != tt.expected {
+			t.Errorf("program.String() wrong. expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+}
+
+func TestParseErrorFields(t *testing.T) {
+	l := lexer.NewWithFilename("let x = (1 + 2;", "main.svo")
+	p := New(l)
+	p.ParseProgram()
+
+	errors := p.Errors()
+	if len(errors) != 1 {
+		t.Fatalf("parser has wrong number of errors. expected=1, got=%d", len(errors))
+	}
+
+	err := errors[0]
+	if err.Pos.Filename != "main.svo" || err.Pos.Line != 1 || err.Pos.Column != 15 {
+		t.Errorf("wrong position. got=%s", err.Pos)
+	}
+	if err.Expected != token.RPAREN || err.Got != token.SEMICOLON {
+		t.Errorf("wrong expected/got. got=%q/%q", err.Expected, err.Got)
+	}
+	if err.Message != "expected next token to be ), got ; instead" {
+		t.Errorf("wrong message. got=%q", err.Message)
+	}
+	if err.Snippet != "let x = (1 + 2;\n              ^" {
+		t.Errorf("wrong snippet. got=%q", err.Snippet)
+	}
+}
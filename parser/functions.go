@@ -1,55 +1,265 @@
 package parser
 
 import (
+	"fmt"
+
 	"github.com/jumballaya/servo/ast"
 	"github.com/jumballaya/servo/token"
 )
 
 // Parse Function Literal builds the expression that creates the function literal
 func (p *Parser) parseFunctionLiteral() ast.Expression {
-	lit := &ast.FunctionLiteral{Token: p.curToken}
+	lit := p.parseFunction(&ast.FunctionLiteral{Token: p.curToken, Doc: p.curDoc})
+	if lit == nil {
+		return nil
+	}
+	return lit
+}
+
+// Parse Function Statement builds a named function declaration, e.g. `fn add(a, b) { a + b }`
+func (p *Parser) parseFunctionStatement() *ast.FunctionStatement {
+	stmt := &ast.FunctionStatement{Token: p.curToken}
+	doc := p.curDoc
+
+	if !p.expectPeek(token.IDENT) {
+		return nil
+	}
+	stmt.Name = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+
+	stmt.Function = p.parseFunction(&ast.FunctionLiteral{Token: stmt.Token, Name: stmt.Name.Value, Doc: doc})
+	if stmt.Function == nil {
+		return nil
+	}
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
+// Parse Function parses the parameters and body of a function, the next token is its `(`
+func (p *Parser) parseFunction(lit *ast.FunctionLiteral) *ast.FunctionLiteral {
 	if !p.expectPeek(token.LPAREN) {
 		return nil
 	}
 
-	lit.Parameters = p.parseFunctionParameters()
+	lit.Parameters, lit.Rest = p.parseFunctionParameters()
+	if lit.Parameters == nil {
+		return nil
+	}
 	if !p.expectPeek(token.LBRACE) {
 		return nil
 	}
 
-	lit.Body = p.parseBlockStatement()
+	lit.Body = p.parseFunctionBody(p.parseBlockStatement)
+
 	return lit
 }
 
-// Parse Function Parameters builds the expression for the desired parameters of the function
-func (p *Parser) parseFunctionParameters() []*ast.Identifier {
-	identifiers := []*ast.Identifier{}
-	if p.peekTokenIs(token.RPAREN) {
+// Parse Function Body runs parse with the loop depth reset, a function body starts outside of
+// any loop even when it is declared inside one
+func (p *Parser) parseFunctionBody(parse func() *ast.BlockStatement) *ast.BlockStatement {
+	loopDepth := p.loopDepth
+	p.loopDepth = 0
+	body := parse()
+	p.loopDepth = loopDepth
+
+	return body
+}
+
+// Parse Arrow Function builds a function written as `x => x * 2` or `(a, b) => { ... }`. The
+// current token is the single parameter or the `(` of the parameter list.
+func (p *Parser) parseArrowFunction() ast.Expression {
+	lit := &ast.FunctionLiteral{Token: p.curToken, Doc: p.curDoc, Arrow: true}
+
+	if p.curTokenIs(token.IDENT) {
+		lit.Parameters = []ast.Pattern{&ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}}
+	} else {
+		lit.Parameters, lit.Rest = p.parseFunctionParameters()
+		if lit.Parameters == nil {
+			return nil
+		}
+	}
+
+	if !p.expectPeek(token.ARROW) {
+		return nil
+	}
+	lit.Token = p.curToken
+
+	lit.Body = p.parseFunctionBody(p.parseArrowBody)
+	if lit.Body == nil {
+		return nil
+	}
+
+	return lit
+}
+
+// Parse Arrow Body parses what comes after a `=>`: a block, or a single expression that gets
+// wrapped in a block of its own
+func (p *Parser) parseArrowBody() *ast.BlockStatement {
+	if p.peekTokenIs(token.LBRACE) {
 		p.nextToken()
-		return identifiers
+		return p.parseBlockStatement()
 	}
 
 	p.nextToken()
-	ident := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
-	identifiers = append(identifiers, ident)
-	for p.peekTokenIs(token.COMMA) {
+	stmt := &ast.ExpressionStatement{Token: p.curToken, Expression: p.parseExpression(LOWEST)}
+	if stmt.Expression == nil {
+		return nil
+	}
+
+	return &ast.BlockStatement{Token: stmt.Token, Statements: []ast.Statement{stmt}}
+}
+
+// Is Arrow Parameter List checks whether the `(` at the current token starts the parameters of
+// an arrow function. It scans ahead to the matching `)` and looks for a `=>` right after it.
+func (p *Parser) isArrowParameterList() bool {
+	lookahead := p.l.Copy()
+	pending := []token.Token{p.peekToken}
+	for i := len(p.insertedTokens) - 1; i >= 0; i-- {
+		pending = append(pending, p.insertedTokens[i])
+	}
+
+	next := func() token.Token {
+		if len(pending) > 0 {
+			tok := pending[0]
+			pending = pending[1:]
+			return tok
+		}
+		return lookahead.NextToken()
+	}
+
+	depth := 1
+	for {
+		tok := next()
+		switch tok.Type {
+		case token.LPAREN, token.LBRACKET, token.LBRACE:
+			depth++
+		case token.RPAREN, token.RBRACKET, token.RBRACE:
+			depth--
+			if depth == 0 {
+				if tok.Type != token.RPAREN {
+					return false
+				}
+				for {
+					tok = next()
+					if tok.Type != token.DOC_COMMENT {
+						return tok.Type == token.ARROW
+					}
+				}
+			}
+		case token.EOF, token.ILLEGAL:
+			return false
+		}
+	}
+}
+
+// Parse Function Parameters builds the patterns for the desired parameters of the function and
+// the optional rest parameter at the end e.g. `fn(x, [a, b], {name} = {}, ...others)`
+func (p *Parser) parseFunctionParameters() ([]ast.Pattern, *ast.Identifier) {
+	params := []ast.Pattern{}
+	if p.peekTokenIs(token.RPAREN) {
+		p.nextToken()
+		return params, nil
+	}
+
+	var rest *ast.Identifier
+	for {
 		p.nextToken()
+
+		if p.curTokenIs(token.ELLIPSIS) {
+			rest = p.parseRestIdentifier(token.RPAREN)
+			if rest == nil {
+				return nil, nil
+			}
+			break
+		}
+
+		param := p.parseBindingPattern()
+		if param == nil {
+			return nil, nil
+		}
+		params = append(params, param)
+
+		if !p.peekTokenIs(token.COMMA) {
+			break
+		}
 		p.nextToken()
-		ident := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
-		identifiers = append(identifiers, ident)
 	}
 
 	if !p.expectPeek(token.RPAREN) {
-		return nil
+		return nil, nil
 	}
 
-	return identifiers
+	return params, rest
 }
 
 // Parse Call Expression builds the expression of an function when
 // it is being called, e.g. `add(1,2)`
 func (p *Parser) parseCallExpression(function ast.Expression) ast.Expression {
 	exp := &ast.CallExpression{Token: p.curToken, Function: function}
-	exp.Arguments = p.parseExpressionList(token.RPAREN)
+	exp.Arguments = p.parseCallArguments()
+	if exp.Arguments == nil {
+		return nil
+	}
+	return exp
+}
+
+// Parse Call Arguments parses the arguments of a call. Positional arguments, including spread
+// ones like `...args`, come first and named ones like `port: 8080` after them.
+func (p *Parser) parseCallArguments() []ast.Expression {
+	noArrow := p.noArrow
+	p.noArrow = false
+	defer func() { p.noArrow = noArrow }()
+
+	args := []ast.Expression{}
+	if p.peekTokenIs(token.RPAREN) {
+		p.nextToken()
+		return args
+	}
+
+	named := map[string]bool{}
+	for {
+		p.nextToken()
+
+		if p.curTokenIs(token.IDENT) && p.peekTokenIs(token.COLON) {
+			arg := &ast.NamedArgument{Token: p.curToken, Name: &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}}
+			if named[arg.Name.Value] {
+				p.addError(arg.Token.Pos, fmt.Sprintf("argument %s given more than once", arg.Name.Value))
+				return nil
+			}
+			named[arg.Name.Value] = true
+
+			p.nextToken()
+			p.nextToken()
+			arg.Value = p.parseExpression(LOWEST)
+			args = append(args, arg)
+		} else {
+			if len(named) > 0 {
+				p.addError(p.curToken.Pos, "positional argument after named arguments")
+				return nil
+			}
+			args = append(args, p.parseExpression(LOWEST))
+		}
+
+		if !p.peekTokenIs(token.COMMA) {
+			break
+		}
+		p.nextToken()
+	}
+
+	if !p.expectPeek(token.RPAREN) {
+		return nil
+	}
+
+	return args
+}
+
+// Parse Spread Expression e.g. `...args`
+func (p *Parser) parseSpreadExpression() ast.Expression {
+	exp := &ast.SpreadExpression{Token: p.curToken}
+	p.nextToken()
+	exp.Value = p.parseExpression(LOWEST)
 	return exp
 }
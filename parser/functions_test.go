@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/jumballaya/servo/ast"
@@ -175,3 +176,116 @@ func TestCallExpressionParameterParsing(t *testing.T) {
 		}
 	}
 }
+
+func TestCallArguments(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"f(...args)", "f(...args)"},
+		{"f(1, ...rest)", "f(1, ...rest)"},
+		{"listen(port: 8080)", "listen(port: 8080)"},
+		{"f(1, b: 2, c: x + 1)", "f(1, b: 2, c: (x + 1))"},
+		{"fn(a, ...rest) { a }", "fn(a, ...rest) a"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if program.String() != tt.expected {
+			t.Errorf("wrong call. expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+}
+
+func TestInvalidCallArguments(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"f(a: 1, 2)", "1:9: positional argument after named arguments"},
+		{"f(a: 1, a: 2)", "1:9: argument a given more than once"},
+		{"fn(...rest, a) { a }", "1:11: a rest element must be the last one in a pattern"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 {
+			t.Errorf("%s: expected a parser error", tt.input)
+			continue
+		}
+
+		msg := strings.SplitN(errors[0].Error(), "\n", 2)[0]
+		if msg != tt.expected {
+			t.Errorf("wrong error message. expected=%q, got=%q", tt.expected, msg)
+		}
+	}
+}
+
+func TestArrowFunctions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"x => x * 2", "(x) => (x * 2)"},
+		{"() => 1", "() => 1"},
+		{"(a, b) => { a + b }", "(a, b) => (a + b)"},
+		{"([a, b], {c}, d = 1, ...rest) => a", "([a, b], {c}, d = 1, ...rest) => a"},
+		{"map(xs, x => x + 1)", "map(xs, (x) => (x + 1))"},
+		{"let f = (a) => b => a + b", "let f = (a) => (b) => (a + b);"},
+		{"(x + 1) * 2", "((x + 1) * 2)"},
+		{"((a) => a)(1)", "(a) => a(1)"},
+		{"match (x) { n if ok => n }", "match (x) { n if ok => n }"},
+		{"match (x) { n if any(n, y => y) => n }", "match (x) { n if any(n, (y) => y) => n }"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if program.String() != tt.expected {
+			t.Errorf("wrong arrow function. expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+}
+
+func TestFunctionStatement(t *testing.T) {
+	input := `
+## Doubles a number
+fn double(x) { x * 2 };
+fn(x) { x };
+`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 2 {
+		t.Fatalf("program has wrong number of statements. got=%d", len(program.Statements))
+	}
+
+	stmt, ok := program.Statements[0].(*ast.FunctionStatement)
+	if !ok {
+		t.Fatalf("program.Statements[0] is not *ast.FunctionStatement. got=%T", program.Statements[0])
+	}
+	if stmt.String() != "fn double(x) (x * 2)" {
+		t.Errorf("stmt.String() wrong. got=%q", stmt.String())
+	}
+	if stmt.Function.Name != "double" || stmt.Function.Doc != "Doubles a number" {
+		t.Errorf("function name or doc wrong. got=%q, %q", stmt.Function.Name, stmt.Function.Doc)
+	}
+
+	if _, ok := program.Statements[1].(*ast.ExpressionStatement); !ok {
+		t.Errorf("anonymous function is not an expression statement. got=%T", program.Statements[1])
+	}
+}
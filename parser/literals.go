@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 
@@ -12,10 +13,20 @@ import (
 func (p *Parser) parseIntegerLiteral() ast.Expression {
 	lit := &ast.IntegerLiteral{Token: p.curToken}
 
+	// Go would read `010` as the C-style octal 8, octal has to be written `0o10` instead
+	if l := p.curToken.Literal; len(l) > 1 && l[0] == '0' && (l[1] >= '0' && l[1] <= '9' || l[1] == '_') {
+		msg := fmt.Sprintf("integer literal %q has a leading zero, use 0o for octal", l)
+		p.addError(p.curToken.Pos, msg)
+		return nil
+	}
+
 	value, err := strconv.ParseInt(p.curToken.Literal, 0, 64)
 	if err != nil {
-		msg := fmt.Sprintf("could not parse %q as integer", p.curToken.Literal)
-		p.errors = append(p.errors, msg)
+		msg := fmt.Sprintf("malformed integer literal %q", p.curToken.Literal)
+		if errors.Is(err, strconv.ErrRange) {
+			msg = fmt.Sprintf("integer literal %q out of range", p.curToken.Literal)
+		}
+		p.addError(p.curToken.Pos, msg)
 		return nil
 	}
 
@@ -29,8 +40,11 @@ func (p *Parser) parseFloatLiteral() ast.Expression {
 
 	value, err := strconv.ParseFloat(p.curToken.Literal, 64)
 	if err != nil {
-		msg := fmt.Sprintf("could not parse %q as float", p.curToken.Literal)
-		p.errors = append(p.errors, msg)
+		msg := fmt.Sprintf("malformed float literal %q", p.curToken.Literal)
+		if errors.Is(err, strconv.ErrRange) {
+			msg = fmt.Sprintf("float literal %q out of range", p.curToken.Literal)
+		}
+		p.addError(p.curToken.Pos, msg)
 		return nil
 	}
 
@@ -43,6 +57,36 @@ func (p *Parser) parseStringLiteral() ast.Expression {
 	return &ast.StringLiteral{Token: p.curToken, Value: p.curToken.Literal}
 }
 
+// Parse Bytes Literal
+func (p *Parser) parseBytesLiteral() ast.Expression {
+	return &ast.BytesLiteral{Token: p.curToken, Value: []byte(p.curToken.Literal)}
+}
+
+// Parse Template Literal builds the text chunks and substitutions of a template string
+// e.g. `Hello ${user.name}!` becomes the parts "Hello ", user.name and "!"
+func (p *Parser) parseTemplateLiteral() ast.Expression {
+	lit := &ast.TemplateLiteral{Token: p.curToken}
+	lit.Parts = append(lit.Parts, &ast.StringLiteral{Token: p.curToken, Value: p.curToken.Literal})
+
+	for p.curTokenIs(token.TEMPLATE_HEAD) || p.curTokenIs(token.TEMPLATE_MIDDLE) {
+		p.nextToken()
+		exp := p.parseExpression(LOWEST)
+		if exp == nil {
+			return nil
+		}
+		lit.Parts = append(lit.Parts, exp)
+
+		if p.peekTokenIs(token.TEMPLATE_MIDDLE) {
+			p.nextToken()
+		} else if !p.expectPeek(token.TEMPLATE_TAIL) {
+			return nil
+		}
+		lit.Parts = append(lit.Parts, &ast.StringLiteral{Token: p.curToken, Value: p.curToken.Literal})
+	}
+
+	return lit
+}
+
 // Parse Boolean Literal
 func (p *Parser) parseBooleanLiteral() ast.Expression {
 	return &ast.BooleanLiteral{
@@ -53,6 +97,9 @@ func (p *Parser) parseBooleanLiteral() ast.Expression {
 
 // Parse Identifier
 func (p *Parser) parseIdentifier() ast.Expression {
+	if p.peekTokenIs(token.ARROW) && !p.noArrow {
+		return p.parseArrowFunction()
+	}
 	return &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
 }
 
@@ -60,8 +107,3 @@ func (p *Parser) parseIdentifier() ast.Expression {
 func (p *Parser) parseNullLiteral() ast.Expression {
 	return &ast.NullLiteral{Token: p.curToken}
 }
-
-// Parse Comment Literal
-func (p *Parser) parseCommentLiteral() ast.Expression {
-	return &ast.CommentLiteral{Token: p.curToken, Value: p.curToken.Literal}
-}
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/jumballaya/servo/ast"
@@ -100,6 +101,88 @@ func TestFloatLiteralExpression(t *testing.T) {
 	}
 }
 
+func TestNumberLiteralForms(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected interface{}
+	}{
+		{"0", int64(0)},
+		{"10", int64(10)},
+		{"0xff", int64(255)},
+		{"0XFF", int64(255)},
+		{"0o755", int64(493)},
+		{"0b1010", int64(10)},
+		{"1_000_000", int64(1000000)},
+		{"0xdead_beef", int64(0xdeadbeef)},
+		{"1.5e-3", 1.5e-3},
+		{"2E10", 2e10},
+		{"1e+2", 100.0},
+		{"1_000.000_5", 1000.0005},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		switch expected := tt.expected.(type) {
+		case int64:
+			literal, ok := stmt.Expression.(*ast.IntegerLiteral)
+			if !ok {
+				t.Fatalf("exp not *ast.IntegerLiteral. got=%T", stmt.Expression)
+			}
+			if literal.Value != expected {
+				t.Errorf("%s: literal.Value not %d. got=%d", tt.input, expected, literal.Value)
+			}
+		case float64:
+			literal, ok := stmt.Expression.(*ast.FloatLiteral)
+			if !ok {
+				t.Fatalf("exp not *ast.FloatLiteral. got=%T", stmt.Expression)
+			}
+			if literal.Value != expected {
+				t.Errorf("%s: literal.Value not %f. got=%f", tt.input, expected, literal.Value)
+			}
+		}
+	}
+}
+
+func TestMalformedNumberLiterals(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"1.2.3", `1:1: malformed float literal "1.2.3"`},
+		{"0b102", `1:1: malformed integer literal "0b102"`},
+		{"0x", `1:1: malformed integer literal "0x"`},
+		{"12abc", `1:1: malformed integer literal "12abc"`},
+		{"1__000", `1:1: malformed integer literal "1__000"`},
+		{"1e", `1:1: malformed float literal "1e"`},
+		{"010", `1:1: integer literal "010" has a leading zero, use 0o for octal`},
+		{"09", `1:1: integer literal "09" has a leading zero, use 0o for octal`},
+		{"0_1", `1:1: integer literal "0_1" has a leading zero, use 0o for octal`},
+		{"99999999999999999999", `1:1: integer literal "99999999999999999999" out of range`},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 {
+			t.Errorf("%s: expected a parser error", tt.input)
+			continue
+		}
+
+		msg := strings.SplitN(errors[0].Error(), "\n", 2)[0]
+		if msg != tt.expected {
+			t.Errorf("wrong error message. expected=%q, got=%q", tt.expected, msg)
+		}
+	}
+}
+
 func TestBooleanExpression(t *testing.T) {
 	tests := []struct {
 		input           string
@@ -156,9 +239,8 @@ func TestStringLiteralExpression(t *testing.T) {
 	}
 }
 
-func TestParsingComments(t *testing.T) {
-	input := "#This is a comment"
-	expected := "This is a comment"
+func TestBytesLiteralExpression(t *testing.T) {
+	input := `b'hello\x00';`
 
 	l := lexer.New(input)
 	p := New(l)
@@ -166,12 +248,116 @@ func TestParsingComments(t *testing.T) {
 	checkParserErrors(t, p)
 
 	stmt := program.Statements[0].(*ast.ExpressionStatement)
-	literal, ok := stmt.Expression.(*ast.CommentLiteral)
+	literal, ok := stmt.Expression.(*ast.BytesLiteral)
 	if !ok {
-		t.Fatalf("exp not *ast.CommentLiteral. got=%T", stmt.Expression)
+		t.Fatalf("exp not *ast.BytesLiteral. got=%T", stmt.Expression)
+	}
+
+	if string(literal.Value) != "hello\x00" {
+		t.Errorf("literal.Value not %q. got=%q", "hello\x00", literal.Value)
+	}
+}
+
+func TestTemplateLiteralExpression(t *testing.T) {
+	tests := []struct {
+		input         string
+		expectedParts int
+		expected      string
+	}{
+		{"`hello`", 1, "`hello`"},
+		{"`Hello ${user.name}!`", 3, "`Hello ${(user.name)}!`"},
+		{"`${a + b}${len(c)}`", 5, "`${(a + b)}${len(c)}`"},
+		{"`line one\n${x}\nline two`", 3, "`line one\n${x}\nline two`"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		literal, ok := stmt.Expression.(*ast.TemplateLiteral)
+		if !ok {
+			t.Fatalf("exp not *ast.TemplateLiteral. got=%T", stmt.Expression)
+		}
+
+		if len(literal.Parts) != tt.expectedParts {
+			t.Errorf("template has wrong number of parts. expected=%d, got=%d",
+				tt.expectedParts, len(literal.Parts))
+		}
+
+		if literal.String() != tt.expected {
+			t.Errorf("literal.String() wrong. expected=%q, got=%q", tt.expected, literal.String())
+		}
+	}
+}
+
+func TestParsingComments(t *testing.T) {
+	input := `# This is a comment
+/* so is
+   this */
+5; # trailing`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 1 {
+		t.Fatalf("comments should not become statements. got=%d statements",
+			len(program.Statements))
+	}
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	testIntegerLiteral(t, stmt.Expression, 5)
+}
+
+func TestParsingDocComments(t *testing.T) {
+	input := `
+## Adds two numbers
+## together
+let add = fn(x, y) { x + y };
+
+## A greeter
+class Greeter {
+	## Says hello
+	let greet = fn() { "hello" };
+};
+
+## Ignored, nothing to attach to
+5;
+let plain = 1;
+`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	if len(program.Statements) != 4 {
+		t.Fatalf("program has wrong number of statements. got=%d", len(program.Statements))
+	}
+
+	add := program.Statements[0].(*ast.LetStatement)
+	if add.Doc != "Adds two numbers\ntogether" {
+		t.Errorf("let doc wrong. got=%q", add.Doc)
+	}
+	if fn := add.Value.(*ast.FunctionLiteral); fn.Doc != add.Doc {
+		t.Errorf("function doc wrong. got=%q", fn.Doc)
+	}
+
+	greeter := program.Statements[1].(*ast.LetStatement)
+	class := greeter.Value.(*ast.ClassLiteral)
+	if greeter.Doc != "A greeter" || class.Doc != "A greeter" {
+		t.Errorf("class doc wrong. got=%q, %q", greeter.Doc, class.Doc)
+	}
+	if class.Fields[0].Doc != "Says hello" {
+		t.Errorf("class field doc wrong. got=%q", class.Fields[0].Doc)
 	}
 
-	if literal.Value != expected {
-		t.Errorf("literal.Value not %q. got=%q", expected, literal.Value)
+	plain := program.Statements[3].(*ast.LetStatement)
+	if plain.Doc != "" {
+		t.Errorf("doc attached to the wrong statement. got=%q", plain.Doc)
 	}
 }
@@ -0,0 +1,112 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/jumballaya/servo/ast"
+	"github.com/jumballaya/servo/token"
+)
+
+// Parse For Statement builds a for-in loop, e.g. `for (x in arr) { ... }` or
+// `for (k, v in hash) { ... }`
+func (p *Parser) parseForStatement() ast.Statement {
+	stmt := &ast.ForStatement{Token: p.curToken}
+
+	if !p.expectPeek(token.LPAREN) {
+		return nil
+	}
+
+	if !p.expectPeek(token.IDENT) {
+		return nil
+	}
+	stmt.Value = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+
+	if p.peekTokenIs(token.COMMA) {
+		p.nextToken()
+		if !p.expectPeek(token.IDENT) {
+			return nil
+		}
+		stmt.Key = stmt.Value
+		stmt.Value = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	}
+
+	if !p.expectPeek(token.IN) {
+		return nil
+	}
+
+	p.nextToken()
+	stmt.Iterable = p.parseExpression(LOWEST)
+
+	if !p.expectPeek(token.RPAREN) {
+		return nil
+	}
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	stmt.Body = p.parseLoopBody()
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
+// Parse While Statement builds a loop that runs while its condition is truthy,
+// e.g. `while (i < 10) { ... }`
+func (p *Parser) parseWhileStatement() ast.Statement {
+	stmt := &ast.WhileStatement{Token: p.curToken}
+
+	if !p.expectPeek(token.LPAREN) {
+		return nil
+	}
+
+	p.nextToken()
+	stmt.Condition = p.parseExpression(LOWEST)
+
+	if !p.expectPeek(token.RPAREN) {
+		return nil
+	}
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	stmt.Body = p.parseLoopBody()
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
+// Parse Loop Body parses the block of a loop, keeping track of the loop depth so break and
+// continue can be checked
+func (p *Parser) parseLoopBody() *ast.BlockStatement {
+	p.loopDepth++
+	defer func() { p.loopDepth-- }()
+
+	return p.parseBlockStatement()
+}
+
+// Parse Loop Control Statement builds a `break` or `continue` statement. Both are errors
+// outside of a loop.
+func (p *Parser) parseLoopControlStatement() ast.Statement {
+	tok := p.curToken
+
+	if p.loopDepth == 0 {
+		p.addError(tok.Pos, fmt.Sprintf("%s outside of a loop", tok.Literal))
+	}
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	if tok.Type == token.BREAK {
+		return &ast.BreakStatement{Token: tok}
+	}
+	return &ast.ContinueStatement{Token: tok}
+}
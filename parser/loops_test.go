@@ -0,0 +1,104 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jumballaya/servo/ast"
+	"github.com/jumballaya/servo/lexer"
+)
+
+func TestForStatement(t *testing.T) {
+	tests := []struct {
+		input    string
+		key      string
+		value    string
+		expected string
+	}{
+		{"for (x in arr) { x }", "", "x", "for (x in arr) x"},
+		{"for (k, v in h) { puts(k, v); };", "k", "v", "for (k, v in h) puts(k, v)"},
+		{"for (c in \"abc\") { c }", "", "c", "for (c in abc) c"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("program has wrong number of statements. got=%d", len(program.Statements))
+		}
+
+		stmt, ok := program.Statements[0].(*ast.ForStatement)
+		if !ok {
+			t.Fatalf("program.Statements[0] is not ast.ForStatement. got=%T", program.Statements[0])
+		}
+
+		if tt.key == "" && stmt.Key != nil {
+			t.Errorf("stmt.Key should be nil. got=%s", stmt.Key)
+		}
+		if tt.key != "" && (stmt.Key == nil || stmt.Key.Value != tt.key) {
+			t.Errorf("stmt.Key wrong. expected=%s, got=%v", tt.key, stmt.Key)
+		}
+		if stmt.Value.Value != tt.value {
+			t.Errorf("stmt.Value wrong. expected=%s, got=%s", tt.value, stmt.Value.Value)
+		}
+		if stmt.String() != tt.expected {
+			t.Errorf("stmt.String() wrong. expected=%q, got=%q", tt.expected, stmt.String())
+		}
+	}
+}
+
+func TestWhileStatement(t *testing.T) {
+	input := `while (i < 10) { if (i == 5) { break; } continue; }`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	stmt, ok := program.Statements[0].(*ast.WhileStatement)
+	if !ok {
+		t.Fatalf("program.Statements[0] is not ast.WhileStatement. got=%T", program.Statements[0])
+	}
+
+	if !testInfixExpression(t, stmt.Condition, "i", "<", 10) {
+		return
+	}
+
+	if len(stmt.Body.Statements) != 2 {
+		t.Fatalf("body has wrong number of statements. got=%d", len(stmt.Body.Statements))
+	}
+	if _, ok := stmt.Body.Statements[1].(*ast.ContinueStatement); !ok {
+		t.Errorf("stmt.Body.Statements[1] is not ast.ContinueStatement. got=%T", stmt.Body.Statements[1])
+	}
+}
+
+func TestLoopControlOutsideLoop(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"break;", "1:1: break outside of a loop"},
+		{"if (true) { continue; }", "1:13: continue outside of a loop"},
+		{"while (true) { let f = fn() { break; }; }", "1:31: break outside of a loop"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 {
+			t.Errorf("%s: expected a parser error", tt.input)
+			continue
+		}
+
+		msg := strings.SplitN(errors[0].Error(), "\n", 2)[0]
+		if msg != tt.expected {
+			t.Errorf("wrong error message. expected=%q, got=%q", tt.expected, msg)
+		}
+	}
+}
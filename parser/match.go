@@ -0,0 +1,155 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/jumballaya/servo/ast"
+	"github.com/jumballaya/servo/token"
+)
+
+// Parse Match Expression parses `match (subject) { pattern if guard => body, ... }`. An arm's
+// body is either a single expression or a block
+func (p *Parser) parseMatchExpression() ast.Expression {
+	exp := &ast.MatchExpression{Token: p.curToken}
+
+	if !p.expectPeek(token.LPAREN) {
+		return nil
+	}
+
+	p.nextToken()
+	exp.Subject = p.parseExpression(LOWEST)
+
+	if !p.expectPeek(token.RPAREN) {
+		return nil
+	}
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	for !p.peekTokenIs(token.RBRACE) {
+		p.nextToken()
+
+		arm := p.parseMatchArm()
+		if arm == nil {
+			return nil
+		}
+		exp.Arms = append(exp.Arms, arm)
+
+		// Arms with a block body don't need a comma after them
+		if p.peekTokenIs(token.COMMA) {
+			p.nextToken()
+		} else if !p.curTokenIs(token.RBRACE) && !p.peekTokenIs(token.RBRACE) {
+			p.peekError(token.COMMA)
+			return nil
+		}
+	}
+
+	if !p.expectPeek(token.RBRACE) {
+		return nil
+	}
+
+	return exp
+}
+
+// Parse Match Arm
+func (p *Parser) parseMatchArm() *ast.MatchArm {
+	arm := &ast.MatchArm{Pattern: p.parseMatchPattern()}
+	if arm.Pattern == nil {
+		return nil
+	}
+
+	if p.peekTokenIs(token.IF) {
+		p.nextToken()
+		p.nextToken()
+		p.noArrow = true
+		arm.Guard = p.parseExpression(LOWEST)
+		p.noArrow = false
+	}
+
+	if !p.expectPeek(token.ARROW) {
+		return nil
+	}
+
+	arm.Body = p.parseArrowBody()
+	if arm.Body == nil {
+		return nil
+	}
+
+	return arm
+}
+
+// Parse Match Pattern builds a pattern for a match arm: `_`, a name to bind, a literal, a range
+// like `1..10`, a class pattern like `car instanceof Car`, or array and hash shapes made of
+// those
+func (p *Parser) parseMatchPattern() ast.Pattern {
+	switch p.curToken.Type {
+	case token.IDENT:
+		if p.peekTokenIs(token.INSTANCEOF) {
+			name := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+			p.nextToken()
+			pattern := p.parseClassPattern()
+			if pattern == nil {
+				return nil
+			}
+			if name.Value != "_" {
+				pattern.Name = name
+			}
+			return pattern
+		}
+		if p.curToken.Literal == "_" {
+			return &ast.WildcardPattern{Token: p.curToken}
+		}
+		return &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	case token.INSTANCEOF:
+		if pattern := p.parseClassPattern(); pattern != nil {
+			return pattern
+		}
+		return nil
+	case token.LBRACKET:
+		return p.parseArrayPattern(p.parseMatchPattern)
+	case token.LBRACE:
+		return p.parseHashPattern(p.parseMatchPattern)
+	case token.INT, token.FLOAT, token.STRING, token.BYTES, token.TRUE, token.FALSE, token.NULL, token.MINUS:
+		return p.parseLiteralPattern()
+	}
+
+	msg := fmt.Sprintf("expected a pattern, got %s", p.curToken.Type)
+	p.addError(p.curToken.Pos, msg)
+	return nil
+}
+
+// Parse Class Pattern parses `instanceof Class`, the current token is 'instanceof'
+func (p *Parser) parseClassPattern() *ast.ClassPattern {
+	pattern := &ast.ClassPattern{Token: p.curToken}
+
+	if !p.expectPeek(token.IDENT) {
+		return nil
+	}
+	pattern.Class = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+
+	return pattern
+}
+
+// Parse Literal Pattern parses a literal, or a range when it is followed by `..`
+func (p *Parser) parseLiteralPattern() ast.Pattern {
+	literal := &ast.LiteralPattern{Token: p.curToken, Value: p.parseExpression(PREFIX)}
+	if literal.Value == nil {
+		return nil
+	}
+
+	if !p.peekTokenIs(token.DOTDOT) {
+		return literal
+	}
+
+	p.nextToken()
+	pattern := &ast.RangePattern{Token: p.curToken, Low: literal.Value}
+
+	p.nextToken()
+	pattern.High = p.parseExpression(PREFIX)
+	if pattern.High == nil {
+		return nil
+	}
+
+	return pattern
+}
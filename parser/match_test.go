@@ -0,0 +1,84 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jumballaya/servo/ast"
+	"github.com/jumballaya/servo/lexer"
+)
+
+func TestMatchExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"match (x) { 1 => a, _ => b }", "match (x) { 1 => a, _ => b }"},
+		{"match (x) { -1 => a, 1..10 => b, 'a'..'z' => c }", "match (x) { (-1) => a, 1..10 => b, a..z => c }"},
+		{"match (x) { n if n > 0 => n, }", "match (x) { n if (n > 0) => n }"},
+		{"match (x) { [a, 0, ...rest] => a }", "match (x) { [a, 0, ...rest] => a }"},
+		{`match (req) { {method: "GET", path} => path }`, "match (req) { {method: GET, path} => path }"},
+		{"match (x) { c instanceof Car => c, instanceof Bike => 1, _ instanceof Boat => 2 }", "match (x) { c instanceof Car => c, instanceof Bike => 1, instanceof Boat => 2 }"},
+		{"match (x) { null => { let y = 1; y } _ => 2 }", "match (x) { null => let y = 1;y, _ => 2 }"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("program has wrong number of statements. got=%d", len(program.Statements))
+		}
+
+		stmt := program.Statements[0].(*ast.ExpressionStatement)
+		if _, ok := stmt.Expression.(*ast.MatchExpression); !ok {
+			t.Fatalf("exp is not *ast.MatchExpression. got=%T", stmt.Expression)
+		}
+
+		if program.String() != tt.expected {
+			t.Errorf("program.String() wrong. expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+}
+
+func TestInvalidMatchExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"match (x) { + => 1 }", "1:13: expected a pattern, got +"},
+		{"match (x) { 1 => a 2 => b }", "1:20: expected next token to be ,, got INT instead"},
+		{"match (x) { instanceof 1 => a }", "1:24: expected next token to be IDENT, got INT instead"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 {
+			t.Errorf("%s: expected a parser error", tt.input)
+			continue
+		}
+
+		msg := strings.SplitN(errors[0].Error(), "\n", 2)[0]
+		if msg != tt.expected {
+			t.Errorf("wrong error message. expected=%q, got=%q", tt.expected, msg)
+		}
+	}
+}
+
+func TestKeywordAttributeNames(t *testing.T) {
+	l := lexer.New(`re.match("a"); x?.match`)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	expected := "(re.match)(a)(x?.match)"
+	if program.String() != expected {
+		t.Errorf("program.String() wrong. expected=%q, got=%q", expected, program.String())
+	}
+}
@@ -1,8 +1,6 @@
 package parser
 
 import (
-	"fmt"
-
 	"github.com/jumballaya/servo/ast"
 	"github.com/jumballaya/servo/lexer"
 	"github.com/jumballaya/servo/token"
@@ -11,6 +9,8 @@ import (
 const (
 	_ int = iota
 	LOWEST
+	TERNARY     // cond ? a : b
+	COALESCE    // a ?? b
 	COMPARE     // &&, ||
 	EQUALS      // ==
 	LESSGREATER // > or <
@@ -23,6 +23,8 @@ const (
 )
 
 var precedences = map[token.TokenType]int{
+	token.QMARK:          TERNARY,
+	token.COALESCE:       COALESCE,
 	token.AND:            COMPARE,
 	token.OR:             COMPARE,
 	token.EQ:             EQUALS,
@@ -46,11 +48,19 @@ var precedences = map[token.TokenType]int{
 	token.LPAREN:         CALL,
 	token.LBRACKET:       INDEX,
 	token.DOT:            INDEX,
+	token.QDOT:           INDEX,
 	token.ASSIGN:         ASSIGN,
 	token.PLUSASSIGN:     ASSIGN,
 	token.MINUSASSIGN:    ASSIGN,
 	token.ASTERISKASSIGN: ASSIGN,
 	token.SLASHASSIGN:    ASSIGN,
+
+	token.MODULOASSIGN:     ASSIGN,
+	token.CARROTASSIGN:     ASSIGN,
+	token.BITWISEORASSIGN:  ASSIGN,
+	token.BITWISEANDASSIGN: ASSIGN,
+	token.SHIFTLEFTASSIGN:  ASSIGN,
+	token.SHIFTRIGHTASSIGN: ASSIGN,
 }
 
 type (
@@ -62,14 +72,31 @@ type (
 // It is a simple implementation of a recursive-descent parser.
 type Parser struct {
 	l      *lexer.Lexer
-	errors []string
+	errors []*ParseError
+
+	// Set after an error until the parser synchronizes at the next statement
+	panicking bool
+
+	// How many braces are open at the current token
+	depth int
 
 	lastToken token.Token
 	curToken  token.Token
 	peekToken token.Token
 
+	// `##` doc comments found right before the current and peek tokens
+	curDoc  string
+	peekDoc string
+
 	insertedTokens []token.Token
 
+	// How many loops enclose the current token, break and continue are only allowed inside one
+	loopDepth int
+
+	// Set while parsing a match guard, where `=>` ends the guard instead of starting an arrow
+	// function. Parentheses and call arguments allow arrow functions again.
+	noArrow bool
+
 	prefixParseFns map[token.TokenType]prefixParseFn
 	infixParseFns  map[token.TokenType]infixParseFn
 }
@@ -78,7 +105,7 @@ type Parser struct {
 func New(l *lexer.Lexer) *Parser {
 	p := &Parser{
 		l:              l,
-		errors:         []string{},
+		errors:         []*ParseError{},
 		insertedTokens: make([]token.Token, 0, 5),
 	}
 
@@ -101,13 +128,17 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerPrefix(token.IF, p.parseIfExpression)
 	p.registerPrefix(token.FUNCTION, p.parseFunctionLiteral)
 	p.registerPrefix(token.STRING, p.parseStringLiteral)
+	p.registerPrefix(token.BYTES, p.parseBytesLiteral)
+	p.registerPrefix(token.TEMPLATE, p.parseTemplateLiteral)
+	p.registerPrefix(token.TEMPLATE_HEAD, p.parseTemplateLiteral)
 	p.registerPrefix(token.LBRACKET, p.parseArrayLiteral)
 	p.registerPrefix(token.LBRACE, p.parseHashLiteral)
-	p.registerPrefix(token.COMMENT, p.parseCommentLiteral)
 	p.registerPrefix(token.IMPORT, p.parseImportStatement)
 	p.registerPrefix(token.NULL, p.parseNullLiteral)
 	p.registerPrefix(token.CLASS, p.parseClassLiteral)
 	p.registerPrefix(token.NEW, p.parseNewExpression)
+	p.registerPrefix(token.MATCH, p.parseMatchExpression)
+	p.registerPrefix(token.ELLIPSIS, p.parseSpreadExpression)
 
 	p.registerInfix(token.PLUS, p.parseInfixExpression)
 	p.registerInfix(token.MINUS, p.parseInfixExpression)
@@ -129,6 +160,12 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerInfix(token.MINUSASSIGN, p.parseReassignExpression)
 	p.registerInfix(token.ASTERISKASSIGN, p.parseReassignExpression)
 	p.registerInfix(token.SLASHASSIGN, p.parseReassignExpression)
+	p.registerInfix(token.MODULOASSIGN, p.parseReassignExpression)
+	p.registerInfix(token.CARROTASSIGN, p.parseReassignExpression)
+	p.registerInfix(token.BITWISEORASSIGN, p.parseReassignExpression)
+	p.registerInfix(token.BITWISEANDASSIGN, p.parseReassignExpression)
+	p.registerInfix(token.SHIFTLEFTASSIGN, p.parseReassignExpression)
+	p.registerInfix(token.SHIFTRIGHTASSIGN, p.parseReassignExpression)
 	p.registerInfix(token.CARROT, p.parseInfixExpression)
 	p.registerInfix(token.AND, p.parseInfixExpression)
 	p.registerInfix(token.OR, p.parseInfixExpression)
@@ -136,13 +173,16 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerInfix(token.LBRACKET, p.parseIndexExpression)
 	p.registerInfix(token.ASSIGN, p.parseReassignExpression)
 	p.registerInfix(token.DOT, p.parseAttributeExpression)
+	p.registerInfix(token.QDOT, p.parseAttributeExpression)
+	p.registerInfix(token.QMARK, p.parseTernaryExpression)
+	p.registerInfix(token.COALESCE, p.parseInfixExpression)
 	p.registerInfix(token.INSTANCEOF, p.parseInfixExpression)
 
 	return p
 }
 
 // Errors returns the list of parser errors
-func (p *Parser) Errors() []string {
+func (p *Parser) Errors() []*ParseError {
 	return p.errors
 }
 
@@ -150,22 +190,46 @@ func (p *Parser) Errors() []string {
 func (p *Parser) nextToken() {
 	p.lastToken = p.curToken
 	p.curToken = p.peekToken
+	p.curDoc = p.peekDoc
 	p.advancePeekToken()
 
-	if p.curTokenIs(token.ILLEGAL) {
-		msg := fmt.Sprintf("got illegal token: %s", p.curToken.Literal)
-		p.errors = append(p.errors, msg)
+	switch p.curToken.Type {
+	case token.LBRACE:
+		p.depth++
+	case token.RBRACE:
+		if p.depth > 0 {
+			p.depth--
+		}
+	case token.ILLEGAL:
+		// Illegal tokens carry the lexer's description of the problem as their literal. They
+		// are always reported, even in panic mode, since they aren't caused by an earlier error.
+		p.reportError(&ParseError{Pos: p.curToken.Pos, Got: token.ILLEGAL, Message: p.curToken.Literal})
+		p.panicking = true
 		p.nextToken()
 	}
 }
 
-// Advance Peek Token
+// Advance Peek Token reads the next token. Doc comments are collected into peekDoc
+// instead of becoming tokens of their own.
 func (p *Parser) advancePeekToken() {
-	if len(p.insertedTokens) > 0 {
-		p.peekToken = p.insertedTokens[len(p.insertedTokens)-1]
-		p.insertedTokens = p.insertedTokens[:len(p.insertedTokens)-1]
-	} else {
-		p.peekToken = p.l.NextToken()
+	p.peekDoc = ""
+
+	for {
+		if len(p.insertedTokens) > 0 {
+			p.peekToken = p.insertedTokens[len(p.insertedTokens)-1]
+			p.insertedTokens = p.insertedTokens[:len(p.insertedTokens)-1]
+		} else {
+			p.peekToken = p.l.NextToken()
+		}
+
+		if !p.peekTokenIs(token.DOC_COMMENT) {
+			return
+		}
+
+		if p.peekDoc != "" {
+			p.peekDoc += "\n"
+		}
+		p.peekDoc += p.peekToken.Literal
 	}
 }
 
@@ -175,13 +239,7 @@ func (p *Parser) ParseProgram() *ast.Program {
 	program := &ast.Program{}
 	program.Statements = []ast.Statement{}
 
-	for !p.curTokenIs(token.EOF) {
-		stmt := p.parseStatement()
-		if stmt != nil {
-			program.Statements = append(program.Statements, stmt)
-		}
-		p.nextToken()
-	}
+	program.Statements = p.parseStatements(0)
 
 	return program
 }
@@ -237,6 +295,14 @@ func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
 // Parse Grouped Expression builds the expressions between parenthesis
 // e.g. `add(2,3,4,5)` or `(1 / 2) + (7 * (3 / 2))`
 func (p *Parser) parseGroupedExpression() ast.Expression {
+	if !p.noArrow && (p.peekTokenIs(token.RPAREN) || p.isArrowParameterList()) {
+		return p.parseArrowFunction()
+	}
+
+	noArrow := p.noArrow
+	p.noArrow = false
+	defer func() { p.noArrow = noArrow }()
+
 	p.nextToken()
 	exp := p.parseExpression(LOWEST)
 	if !p.expectPeek(token.RPAREN) {
@@ -250,19 +316,36 @@ func (p *Parser) parseGroupedExpression() ast.Expression {
 // of a function declaration
 func (p *Parser) parseBlockStatement() *ast.BlockStatement {
 	block := &ast.BlockStatement{Token: p.curToken}
-	block.Statements = []ast.Statement{}
+	depth := p.depth
 
 	p.nextToken()
+	block.Statements = p.parseStatements(depth)
+
+	return block
+}
+
+// Parse Statements parses statements starting at the current token until the block at depth
+// is closed, leaving the current token on its `}` or on EOF. A statement with an error is
+// dropped and parsing continues after it, so every syntax error in the block is reported.
+func (p *Parser) parseStatements(depth int) []ast.Statement {
+	stmts := []ast.Statement{}
 
-	for !p.curTokenIs(token.RBRACE) && !p.curTokenIs(token.EOF) {
+	for p.depth >= depth && !p.curTokenIs(token.EOF) {
 		stmt := p.parseStatement()
-		if stmt != nil {
-			block.Statements = append(block.Statements, stmt)
+		if p.panicking {
+			p.synchronize(depth)
+		} else if stmt != nil {
+			stmts = append(stmts, stmt)
+		}
+
+		// The statement's error can swallow the `}` closing the block
+		if p.depth < depth {
+			break
 		}
 		p.nextToken()
 	}
 
-	return block
+	return stmts
 }
 
 // Parse Expression Statement is the generic parsing function for a statement that is not a
@@ -306,19 +389,6 @@ func (p *Parser) parseExpressionList(end token.TokenType) []ast.Expression {
 	return list
 }
 
-// Peek Error adds an error stating that the current token is not the given token
-func (p *Parser) peekError(t token.TokenType) {
-	msg := fmt.Sprintf("expected next token to be %s, got %s instead", t, p.peekToken.Type)
-	p.errors = append(p.errors, msg)
-}
-
-// No Prefix Parse Function Error adds an error if there is no prefix parse
-// function for a given token type
-func (p *Parser) noPrefixParseFnError(t token.TokenType) {
-	msg := fmt.Sprintf("no prefix parse function for %s found", t)
-	p.errors = append(p.errors, msg)
-}
-
 // Register Prefix
 func (p *Parser) registerPrefix(tokenType token.TokenType, fn prefixParseFn) {
 	p.prefixParseFns[tokenType] = fn
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/jumballaya/servo/ast"
+	"github.com/jumballaya/servo/lexer"
 )
 
 func testLetStatement(t *testing.T, s ast.Statement, name string) bool {
@@ -188,8 +189,26 @@ func checkParserErrors(t *testing.T, p *Parser) {
 	}
 
 	t.Errorf("parser has %d errors", len(errors))
-	for _, msg := range errors {
-		t.Errorf("parser error: %q", msg)
+	for _, err := range errors {
+		t.Errorf("parser error: %q", err.Error())
 	}
 	t.FailNow()
 }
+
+func TestParserErrorPositions(t *testing.T) {
+	input := "let x = 5;\nlet y = ;"
+
+	l := lexer.NewWithFilename(input, "main.svo")
+	p := New(l)
+	p.ParseProgram()
+
+	errors := p.Errors()
+	if len(errors) != 1 {
+		t.Fatalf("parser has wrong number of errors. expected=1, got=%d", len(errors))
+	}
+
+	expected := "main.svo:2:9: no prefix parse function for ; found\nlet y = ;\n        ^"
+	if errors[0].Error() != expected {
+		t.Errorf("wrong error message. expected=%q, got=%q", expected, errors[0].Error())
+	}
+}
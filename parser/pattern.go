@@ -0,0 +1,160 @@
+package parser
+
+import (
+	"fmt"
+
+	"github.com/jumballaya/servo/ast"
+	"github.com/jumballaya/servo/token"
+)
+
+// Parse Pattern builds the pattern starting at the current token: an identifier, an array
+// pattern like `[a, b, ...rest]` or a hash pattern like `{name, age: years}`
+func (p *Parser) parsePattern() ast.Pattern {
+	switch p.curToken.Type {
+	case token.IDENT:
+		return &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	case token.LBRACKET:
+		return p.parseArrayPattern(p.parseBindingPattern)
+	case token.LBRACE:
+		return p.parseHashPattern(p.parseBindingPattern)
+	}
+
+	msg := fmt.Sprintf("expected an identifier, array pattern or hash pattern, got %s", p.curToken.Type)
+	p.addError(p.curToken.Pos, msg)
+	return nil
+}
+
+// Parse Binding Pattern is a pattern that can be followed by a default value, e.g. `port = 80`
+func (p *Parser) parseBindingPattern() ast.Pattern {
+	pattern := p.parsePattern()
+	if pattern == nil {
+		return nil
+	}
+
+	if !p.peekTokenIs(token.ASSIGN) {
+		return pattern
+	}
+
+	p.nextToken()
+	def := &ast.DefaultPattern{Token: p.curToken, Target: pattern}
+	p.nextToken()
+	def.Default = p.parseExpression(LOWEST)
+
+	return def
+}
+
+// Parse Array Pattern parses each element with parseElement, so the same shape works for
+// let bindings and match arms
+func (p *Parser) parseArrayPattern(parseElement func() ast.Pattern) ast.Pattern {
+	pattern := &ast.ArrayPattern{Token: p.curToken}
+
+	for !p.peekTokenIs(token.RBRACKET) {
+		p.nextToken()
+
+		if p.curTokenIs(token.ELLIPSIS) {
+			pattern.Rest = p.parseRestIdentifier(token.RBRACKET)
+			if pattern.Rest == nil {
+				return nil
+			}
+			break
+		}
+
+		el := parseElement()
+		if el == nil {
+			return nil
+		}
+		pattern.Elements = append(pattern.Elements, el)
+
+		if !p.peekTokenIs(token.RBRACKET) && !p.expectPeek(token.COMMA) {
+			return nil
+		}
+	}
+
+	if !p.expectPeek(token.RBRACKET) {
+		return nil
+	}
+
+	return pattern
+}
+
+// Parse Hash Pattern parses each entry's value with parseValue
+func (p *Parser) parseHashPattern(parseValue func() ast.Pattern) ast.Pattern {
+	pattern := &ast.HashPattern{Token: p.curToken}
+
+	for !p.peekTokenIs(token.RBRACE) {
+		p.nextToken()
+
+		if p.curTokenIs(token.ELLIPSIS) {
+			pattern.Rest = p.parseRestIdentifier(token.RBRACE)
+			if pattern.Rest == nil {
+				return nil
+			}
+			break
+		}
+
+		entry := p.parseHashPatternEntry(parseValue)
+		if entry == nil {
+			return nil
+		}
+		pattern.Entries = append(pattern.Entries, entry)
+
+		if !p.peekTokenIs(token.RBRACE) && !p.expectPeek(token.COMMA) {
+			return nil
+		}
+	}
+
+	if !p.expectPeek(token.RBRACE) {
+		return nil
+	}
+
+	return pattern
+}
+
+// Parse Hash Pattern Entry parses `key`, `key = default`, `key: pattern` or `"key": pattern`
+func (p *Parser) parseHashPatternEntry(parseValue func() ast.Pattern) *ast.HashPatternEntry {
+	if !p.curTokenIs(token.IDENT) && !p.curTokenIs(token.STRING) {
+		msg := fmt.Sprintf("expected a key in hash pattern, got %s", p.curToken.Type)
+		p.addError(p.curToken.Pos, msg)
+		return nil
+	}
+
+	keyToken := token.Token{Type: token.STRING, Literal: p.curToken.Literal, Pos: p.curToken.Pos}
+	entry := &ast.HashPatternEntry{Key: &ast.StringLiteral{Token: keyToken, Value: p.curToken.Literal}}
+
+	if p.peekTokenIs(token.COLON) {
+		p.nextToken()
+		p.nextToken()
+		entry.Value = parseValue()
+		if entry.Value == nil {
+			return nil
+		}
+		return entry
+	}
+
+	// Shorthand, the key is also the name that gets bound
+	if !p.curTokenIs(token.IDENT) {
+		msg := fmt.Sprintf("string key %q in hash pattern needs a pattern to bind to", p.curToken.Literal)
+		p.addError(p.curToken.Pos, msg)
+		return nil
+	}
+	entry.Value = parseValue()
+	if entry.Value == nil {
+		return nil
+	}
+	return entry
+}
+
+// Parse Rest Identifier parses the name after `...`, which must be the last thing in the pattern
+func (p *Parser) parseRestIdentifier(end token.TokenType) *ast.Identifier {
+	if !p.expectPeek(token.IDENT) {
+		return nil
+	}
+	rest := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+
+	if !p.peekTokenIs(end) {
+		p.addError(p.peekToken.Pos, "a rest element must be the last one in a pattern")
+		return nil
+	}
+
+	return rest
+}
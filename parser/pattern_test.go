@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jumballaya/servo/ast"
+	"github.com/jumballaya/servo/lexer"
+)
+
+func TestLetPatterns(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let [a, b] = arr;", "let [a, b] = arr;"},
+		{"let [a, b, ...rest] = arr;", "let [a, b, ...rest] = arr;"},
+		{"let [] = arr;", "let [] = arr;"},
+		{"let {name, age: years} = user;", "let {name, age: years} = user;"},
+		{`let {"content-type": kind, ...headers} = h;`, "let {content-type: kind, ...headers} = h;"},
+		{"let {port = 80, host: h = \"localhost\"} = config;", "let {port = 80, host: h = localhost} = config;"},
+		{"let [first, {user: {name}}, [x, y] = [0, 0]] = data;", "let [first, {user: {name}}, [x, y] = [0, 0]] = data;"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		stmt, ok := program.Statements[0].(*ast.LetStatement)
+		if !ok {
+			t.Fatalf("program.Statements[0] is not ast.LetStatement. got=%T", program.Statements[0])
+		}
+		if stmt.Pattern == nil || stmt.Name != nil {
+			t.Fatalf("let statement should have a pattern and no name. got pattern=%v name=%v", stmt.Pattern, stmt.Name)
+		}
+
+		if stmt.String() != tt.expected {
+			t.Errorf("stmt.String() wrong. expected=%q, got=%q", tt.expected, stmt.String())
+		}
+	}
+}
+
+func TestFunctionParameterPatterns(t *testing.T) {
+	input := `fn(x, [a, b], {name, ...rest}, y = 10) { x }`
+
+	l := lexer.New(input)
+	p := New(l)
+	program := p.ParseProgram()
+	checkParserErrors(t, p)
+
+	stmt := program.Statements[0].(*ast.ExpressionStatement)
+	function := stmt.Expression.(*ast.FunctionLiteral)
+
+	if len(function.Parameters) != 4 {
+		t.Fatalf("function literal parameters wrong. want 4, got=%d", len(function.Parameters))
+	}
+
+	testLiteralExpression(t, function.Parameters[0], "x")
+	if _, ok := function.Parameters[1].(*ast.ArrayPattern); !ok {
+		t.Errorf("parameter 1 is not ast.ArrayPattern. got=%T", function.Parameters[1])
+	}
+	if _, ok := function.Parameters[2].(*ast.HashPattern); !ok {
+		t.Errorf("parameter 2 is not ast.HashPattern. got=%T", function.Parameters[2])
+	}
+	def, ok := function.Parameters[3].(*ast.DefaultPattern)
+	if !ok {
+		t.Fatalf("parameter 3 is not ast.DefaultPattern. got=%T", function.Parameters[3])
+	}
+	testLiteralExpression(t, def.Target, "y")
+	testLiteralExpression(t, def.Default, 10)
+}
+
+func TestInvalidPatterns(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"let [a, ...rest, b] = arr;", "1:16: a rest element must be the last one in a pattern"},
+		{"let [1] = arr;", "1:6: expected an identifier, array pattern or hash pattern, got INT"},
+		{`let {"a b"} = h;`, `1:6: string key "a b" in hash pattern needs a pattern to bind to`},
+		{"class A { let [a] = [1]; }", "1:11: class fields cannot be destructured"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		p.ParseProgram()
+
+		errors := p.Errors()
+		if len(errors) == 0 {
+			t.Errorf("%s: expected a parser error", tt.input)
+			continue
+		}
+
+		msg := strings.SplitN(errors[0].Error(), "\n", 2)[0]
+		if msg != tt.expected {
+			t.Errorf("wrong error message. expected=%q, got=%q", tt.expected, msg)
+		}
+	}
+}
@@ -237,6 +237,42 @@ func TestOperatorPrecedenceParsing(t *testing.T) {
 			"5 - 7 << 3 * 15 &^ 6",
 			"(5 - (((7 << 3) * 15) &^ 6))",
 		},
+		{
+			"a ? b : c",
+			"(a ? b : c)",
+		},
+		{
+			"a > 1 && b ? c + 1 : d * 2",
+			"(((a > 1) && b) ? (c + 1) : (d * 2))",
+		},
+		{
+			"a ? b : c ? d : e",
+			"(a ? b : (c ? d : e))",
+		},
+		{
+			"a ? b ? c : d : e",
+			"(a ? (b ? c : d) : e)",
+		},
+		{
+			"a ?? b ?? c",
+			"((a ?? b) ?? c)",
+		},
+		{
+			"a || b ?? c",
+			"((a || b) ?? c)",
+		},
+		{
+			"a ?? b ? c : d",
+			"((a ?? b) ? c : d)",
+		},
+		{
+			"req?.body?.user.name",
+			"(((req?.body)?.user).name)",
+		},
+		{
+			"x = a ? 1 : 2",
+			"x = (a ? 1 : 2);",
+		},
 	}
 
 	for _, tt := range tests {
@@ -33,9 +33,10 @@ func (p *Parser) curPrecedence() int {
 	return LOWEST
 }
 
-func createKeywordToken(k string) token.Token {
+func createKeywordToken(k string, pos token.Position) token.Token {
 	return token.Token{
 		Type:    token.LookupIdent(k),
 		Literal: k,
+		Pos:     pos,
 	}
 }
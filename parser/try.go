@@ -0,0 +1,71 @@
+package parser
+
+import (
+	"github.com/jumballaya/servo/ast"
+	"github.com/jumballaya/servo/token"
+)
+
+// Parse Try Statement builds a try statement with a catch block, a finally block or both
+// e.g. `try { ... } catch (e) { ... } finally { ... }`
+func (p *Parser) parseTryStatement() ast.Statement {
+	stmt := &ast.TryStatement{Token: p.curToken}
+
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+	stmt.Block = p.parseBlockStatement()
+
+	if p.peekTokenIs(token.CATCH) {
+		p.nextToken()
+
+		// The error parameter is optional, e.g. `catch { ... }`
+		if p.peekTokenIs(token.LPAREN) {
+			p.nextToken()
+			if !p.expectPeek(token.IDENT) {
+				return nil
+			}
+			stmt.CatchParam = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+			if !p.expectPeek(token.RPAREN) {
+				return nil
+			}
+		}
+
+		if !p.expectPeek(token.LBRACE) {
+			return nil
+		}
+		stmt.Catch = p.parseBlockStatement()
+	}
+
+	if p.peekTokenIs(token.FINALLY) {
+		p.nextToken()
+		if !p.expectPeek(token.LBRACE) {
+			return nil
+		}
+		stmt.Finally = p.parseBlockStatement()
+	}
+
+	if stmt.Catch == nil && stmt.Finally == nil {
+		p.addError(stmt.Token.Pos, "try needs a catch or finally block")
+		return nil
+	}
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
+// Parse Throw Statement builds a throw statement e.g. `throw Error("bad input", "ValueError")`
+func (p *Parser) parseThrowStatement() ast.Statement {
+	stmt := &ast.ThrowStatement{Token: p.curToken}
+
+	p.nextToken()
+	stmt.Value = p.parseExpression(LOWEST)
+
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
@@ -0,0 +1,56 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jumballaya/servo/ast"
+	"github.com/jumballaya/servo/lexer"
+)
+
+func TestTryStatement(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"try { x } catch (e) { y }", "try x catch (e) y"},
+		{"try { x } catch { y } finally { z };", "try x catch y finally z"},
+		{"try { x } finally { z }", "try x finally z"},
+		{"throw Error('bad');", "throw Error(bad);"},
+	}
+
+	for _, tt := range tests {
+		l := lexer.New(tt.input)
+		p := New(l)
+		program := p.ParseProgram()
+		checkParserErrors(t, p)
+
+		if len(program.Statements) != 1 {
+			t.Fatalf("program has wrong number of statements. got=%d", len(program.Statements))
+		}
+
+		switch program.Statements[0].(type) {
+		case *ast.TryStatement, *ast.ThrowStatement:
+		default:
+			t.Fatalf("program.Statements[0] is not a try or throw statement. got=%T", program.Statements[0])
+		}
+
+		if program.String() != tt.expected {
+			t.Errorf("program.String() wrong. expected=%q, got=%q", tt.expected, program.String())
+		}
+	}
+}
+
+func TestTryWithoutHandler(t *testing.T) {
+	l := lexer.New("try { x }")
+	p := New(l)
+	p.ParseProgram()
+
+	errors := p.Errors()
+	if len(errors) == 0 {
+		t.Fatalf("expected a parser error")
+	}
+	if !strings.HasPrefix(errors[0].Error(), "1:1: try needs a catch or finally block") {
+		t.Errorf("wrong error message. got=%q", errors[0].Error())
+	}
+}
@@ -7,6 +7,7 @@ import (
 	prompt "github.com/c-bata/go-prompt"
 	"github.com/jumballaya/servo/evaluator"
 	"github.com/jumballaya/servo/lexer"
+	"github.com/jumballaya/servo/object"
 	"github.com/jumballaya/servo/parser"
 )
 
@@ -20,11 +21,14 @@ func exec(line string) {
 
 	program := p.ParseProgram()
 	if len(p.Errors()) != 0 {
-		for _, e := range p.Errors() {
-			fmt.Println(e)
-		}
+		printParserErrors(os.Stdout, p.Errors())
+		return
 	}
 	evaluated := evaluator.Eval(program, env)
+	if err, ok := evaluated.(*object.Error); ok {
+		printRuntimeError(os.Stdout, err, line, "")
+		return
+	}
 	fmt.Println(evaluated.Inspect())
 }
 
@@ -3,6 +3,9 @@ package repl
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
 
 	"github.com/jumballaya/servo/evaluator"
 	"github.com/jumballaya/servo/lexer"
@@ -15,7 +18,8 @@ import (
 const PROMPT = ">> "
 
 type Config struct {
-	Verbose bool
+	Verbose  bool
+	Filename string
 }
 
 var env *object.Environment
@@ -29,28 +33,63 @@ func Start(in io.Reader, out io.Writer, config *Config) {
 func Run(input string, out io.Writer, config *Config) {
 	//env := stdlib.NewEnvironmentWithLib()
 	env := object.NewEnvironment()
-	l := lexer.New(input)
+	l := lexer.NewWithFilename(input, config.Filename)
 	p := parser.New(l)
 
 	program := p.ParseProgram()
 	if len(p.Errors()) != 0 {
 		printParserErrors(out, p.Errors())
+		return
 	}
 
-	evaluated := evaluator.Eval(program, env)
+	var evaluated object.Object
+	if config.Filename != "" {
+		// The script is the root of the module graph, so files importing it share its state
+		path, err := filepath.Abs(config.Filename)
+		if err != nil {
+			fmt.Fprintln(out, err.Error())
+			return
+		}
+		evaluated = evaluator.RunModule(path, program, env)
+	} else {
+		evaluated = evaluator.Eval(program, env)
+	}
+
+	if err, ok := evaluated.(*object.Error); ok {
+		printRuntimeError(out, err, input, config.Filename)
+		return
+	}
 
 	if config.Verbose {
 		if evaluated != nil {
-			fmt.Fprintf(out, evaluated.Inspect())
-			fmt.Fprintf(out, "\n")
+			fmt.Fprintln(out, evaluated.Inspect())
 		}
 	}
 }
 
-func printParserErrors(out io.Writer, errors []string) {
+func printParserErrors(out io.Writer, errors []*parser.ParseError) {
 	fmt.Fprintf(out, "Woops! We ran into some issues!\n")
 	fmt.Fprintf(out, " parser errors:\n")
-	for _, msg := range errors {
-		fmt.Fprintf(out, "\t"+msg+"\n")
+	for _, err := range errors {
+		fmt.Fprintf(out, "\t%s\n", strings.Replace(err.Error(), "\n", "\n\t", -1))
+	}
+}
+
+// Print Runtime Error prints the error along with a snippet of the source line that caused it.
+// Errors raised inside of an imported file are shown with that file's source.
+func printRuntimeError(out io.Writer, err *object.Error, input, filename string) {
+	fmt.Fprintln(out, err.Inspect())
+
+	src := input
+	if err.Pos.Filename != filename {
+		file, readErr := ioutil.ReadFile(err.Pos.Filename)
+		if readErr != nil {
+			return
+		}
+		src = string(file)
+	}
+
+	if snippet := err.Pos.Snippet(src); snippet != "" {
+		fmt.Fprintln(out, snippet)
 	}
 }
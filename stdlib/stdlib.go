@@ -1,10 +1,9 @@
 package stdlib
 
 import (
-	"fmt"
-	"io/ioutil"
+	"embed"
 	"log"
-	"path/filepath"
+	"strings"
 
 	"github.com/jumballaya/servo/evaluator"
 	"github.com/jumballaya/servo/lexer"
@@ -17,29 +16,34 @@ var Libs = []string{
 	"String",
 }
 
+// The library sources are built into the binary so they load from any working directory
+//
+//go:embed .svo/*.svo
+var sources embed.FS
+
+// New Environment With Lib creates an environment with every standard library module bound to
+// a hash named after it, e.g. `Array`, so `import map from 'Array'` works
 func NewEnvironmentWithLib() *object.Environment {
 	newEnv := object.NewEnvironment()
 	for _, lib := range Libs {
-		// Figure out a common place to put these files
-		currentFile := "./stdlib/.svo/" + lib + ".svo"
-		dir, err := filepath.Abs(currentFile)
-		if err != nil {
-			log.Fatal(err)
-		}
-		file, err := ioutil.ReadFile(dir)
+		path := ".svo/" + lib + ".svo"
+		file, err := sources.ReadFile(path)
 		if err != nil {
-			fmt.Println(err.Error())
 			log.Fatal(err)
 		}
 
 		requiredCode := string(file[:])
 		env := object.NewEnvironment()
-		l := lexer.New(requiredCode)
+		l := lexer.NewWithFilename(requiredCode, "stdlib/"+path)
 		p := parser.New(l)
 
 		program := p.ParseProgram()
 		if len(p.Errors()) != 0 {
-			log.Fatal(p.Errors())
+			msgs := make([]string, len(p.Errors()))
+			for i, err := range p.Errors() {
+				msgs[i] = err.Error()
+			}
+			log.Fatal(strings.Join(msgs, "\n"))
 		}
 
 		embedded := evaluator.Eval(program, env)
@@ -0,0 +1,67 @@
+package token
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Position is a location in a source file. Lines and columns start at 1, columns
+// count characters (runes) and the offset is the byte offset from the start of the file
+// starting at 0.
+type Position struct {
+	Filename string
+	Offset   int
+	Line     int
+	Column   int
+}
+
+// IsValid reports whether the position has been set by the lexer
+func (p Position) IsValid() bool { return p.Line > 0 }
+
+// String formats the position as `file:line:col`, leaving out the file name when
+// there isn't one
+func (p Position) String() string {
+	if !p.IsValid() {
+		if p.Filename != "" {
+			return p.Filename
+		}
+		return "-"
+	}
+
+	if p.Filename == "" {
+		return fmt.Sprintf("%d:%d", p.Line, p.Column)
+	}
+	return fmt.Sprintf("%s:%d:%d", p.Filename, p.Line, p.Column)
+}
+
+// Snippet returns the line of src that the position points to with a caret underneath
+// the column, e.g.
+//
+//	let x = 5 + ;
+//	            ^
+func (p Position) Snippet(src string) string {
+	if !p.IsValid() {
+		return ""
+	}
+
+	lines := strings.Split(src, "\n")
+	if p.Line > len(lines) {
+		return ""
+	}
+	line := strings.TrimRight(lines[p.Line-1], "\r")
+
+	// Keep the tabs from the source line so the caret lines up
+	var pad strings.Builder
+	for i, ch := range []rune(line) {
+		if i >= p.Column-1 {
+			break
+		}
+		if ch == '\t' {
+			pad.WriteByte('\t')
+		} else {
+			pad.WriteByte(' ')
+		}
+	}
+
+	return line + "\n" + pad.String() + "^"
+}
@@ -6,20 +6,28 @@ type TokenType string
 type Token struct {
 	Type    TokenType
 	Literal string
+	Pos     Position
 }
 
 // Token Types
 const (
-	ILLEGAL = "ILLEGAL"
-	EOF     = "EOF"
-	COMMENT = "#"
-	NULL    = "NULL"
+	ILLEGAL     = "ILLEGAL"
+	EOF         = "EOF"
+	DOC_COMMENT = "##"
+	NULL        = "NULL"
 
 	// Identifiers + literals
 	IDENT  = "IDENT"  // add, foobar, x, y, ...
 	INT    = "INT"    // 1343456
 	FLOAT  = "FLOAT"  // 3.1415
 	STRING = "STRING" // "foobar"
+	BYTES  = "BYTES"  // b"foobar"
+
+	// Template literals, e.g. `a${x}b${y}c` is lexed as HEAD("a") x MIDDLE("b") y TAIL("c")
+	TEMPLATE        = "TEMPLATE" // `foobar` without substitutions
+	TEMPLATE_HEAD   = "TEMPLATE_HEAD"
+	TEMPLATE_MIDDLE = "TEMPLATE_MIDDLE"
+	TEMPLATE_TAIL   = "TEMPLATE_TAIL"
 
 	// Operators
 	ASSIGN   = "="
@@ -35,6 +43,8 @@ const (
 	MINUSASSIGN    = "-="
 	ASTERISKASSIGN = "*="
 	SLASHASSIGN    = "/="
+	MODULOASSIGN   = "%="
+	CARROTASSIGN   = "^="
 
 	// Logical Comparison
 	LT     = "<"
@@ -53,13 +63,23 @@ const (
 	SHIFTLEFT     = "<<"
 	SHIFTRIGHT    = ">>"
 
+	BITWISEORASSIGN  = "|="
+	BITWISEANDASSIGN = "&="
+	SHIFTLEFTASSIGN  = "<<="
+	SHIFTRIGHTASSIGN = ">>="
+
 	// Delimiters
 	COMMA      = ","
 	SEMICOLON  = ";"
 	COLON      = ":"
 	COLONCOLON = "::"
 	QMARK      = "?"
+	COALESCE   = "??"
+	QDOT       = "?."
 	DOT        = "."
+	ELLIPSIS   = "..."
+	DOTDOT     = ".."
+	ARROW      = "=>"
 
 	LPAREN   = "("
 	RPAREN   = ")"
@@ -77,13 +97,22 @@ const (
 	ELSE       = "ELSE"
 	FOR        = "FOR"
 	IN         = "IN"
+	WHILE      = "WHILE"
+	BREAK      = "BREAK"
+	CONTINUE   = "CONTINUE"
+	TRY        = "TRY"
+	CATCH      = "CATCH"
+	FINALLY    = "FINALLY"
+	THROW      = "THROW"
 	RETURN     = "RETURN"
 	IMPORT     = "IMPORT"
+	EXPORT     = "EXPORT"
 	FROM       = "FROM"
 	AS         = "AS"
 	CLASS      = "CLASS"
 	NEW        = "NEW"
 	INSTANCEOF = "INSTANCEOF"
+	MATCH      = "MATCH"
 )
 
 var keywords = map[string]TokenType{
@@ -95,14 +124,23 @@ var keywords = map[string]TokenType{
 	"else":       ELSE,
 	"for":        FOR,
 	"in":         IN,
+	"while":      WHILE,
+	"break":      BREAK,
+	"continue":   CONTINUE,
+	"try":        TRY,
+	"catch":      CATCH,
+	"finally":    FINALLY,
+	"throw":      THROW,
 	"return":     RETURN,
 	"import":     IMPORT,
+	"export":     EXPORT,
 	"from":       FROM,
 	"as":         AS,
 	"class":      CLASS,
 	"new":        NEW,
 	"null":       NULL,
 	"instanceof": INSTANCEOF,
+	"match":      MATCH,
 }
 
 // LookupIdent finds the equivalent constant for a given identifier